@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Drives of alternative vehicles.
+const (
+	DriveElectric     = "electric"
+	DrivePlugInHybrid = "plug-in hybrid"
+)
+
+// maxAlternativeSeats is the maximum difference of the number of seats of
+// alternative vehicles.
+const maxAlternativeSeats = 1
+
+// alternativeDrives returns the drives of the power sources of alternative
+// vehicles by their ids, i.e. of the electric and plug-in hybrid ones.
+func alternativeDrives(powerSources []*PowerSource) map[int]string {
+	drives := map[int]string{}
+	for _, ps := range powerSources {
+		c := ps.Classification
+		if c == nil {
+			continue
+		}
+		if c.FuelFamily == FuelFamilyElectric {
+			drives[ps.ID] = DriveElectric
+		} else if c.HybridType == HybridTypePlugIn {
+			drives[ps.ID] = DrivePlugInHybrid
+		}
+	}
+	return drives
+}
+
+// alternativePowerSourceIDs returns the sorted ids of the power sources of
+// the drives.
+func alternativePowerSourceIDs(drives map[int]string) []int {
+	ids := make([]int, 0, len(drives))
+	for id := range drives {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Alternative is an electric or plug-in hybrid vehicle comparable to another
+// vehicle. The similarity is between 0 and 1.
+type Alternative struct {
+	Vehicle      *Vehicle `json:"vehicle"`
+	Drive        string   `json:"drive"`
+	Similarity   float64  `json:"similarity"`
+	Explanations []string `json:"explanations"`
+}
+
+// Weights of the similarity criteria.
+const (
+	powerWeight    = 0.35
+	massWeight     = 0.3
+	seatsWeight    = 0.2
+	bodyworkWeight = 0.15
+)
+
+// FindAlternatives returns at most limit of the candidates, that have one of
+// the drives by power source id and at most one seat more or less than the
+// vehicle, ranked by their similarity to the vehicle.
+func FindAlternatives(vehicle *Vehicle, candidates []*Vehicle, drives map[int]string, limit int) []*Alternative {
+	alternatives := []*Alternative{}
+	for _, c := range candidates {
+		drive, ok := drives[c.PowerSourceID]
+		if !ok || c.ManufacturerID == vehicle.ManufacturerID && c.TSN == vehicle.TSN ||
+			vehicle.Category != "" && c.Category != vehicle.Category ||
+			vehicle.Seats != nil && c.Seats != nil && abs(*c.Seats-*vehicle.Seats) > maxAlternativeSeats {
+			continue
+		}
+		alternative := compareAlternative(vehicle, c)
+		alternative.Drive = drive
+		alternatives = append(alternatives, alternative)
+	}
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		return alternatives[i].Similarity > alternatives[j].Similarity
+	})
+	if limit > 0 && len(alternatives) > limit {
+		alternatives = alternatives[:limit]
+	}
+	return alternatives
+}
+
+// compareAlternative rates the similarity of the candidate to the vehicle by
+// the weighted relative differences of the criteria known for both.
+func compareAlternative(vehicle, candidate *Vehicle) *Alternative {
+	a := &Alternative{Vehicle: candidate, Explanations: []string{}}
+	var weights, differences float64
+
+	compare := func(name, unit string, weight float64, value, other int) {
+		if value <= 0 || other <= 0 {
+			return
+		}
+		difference := math.Abs(float64(other-value)) / math.Max(float64(value), float64(other))
+		weights += weight
+		differences += weight * difference
+		format := func(n int) string { return strings.TrimSpace(fmt.Sprintf("%d %s", n, unit)) }
+		if other == value {
+			a.Explanations = append(a.Explanations, fmt.Sprintf("same %s: %s", name, format(value)))
+		} else {
+			a.Explanations = append(a.Explanations, fmt.Sprintf("%s %s instead of %s (%+.0f%%)",
+				name, format(other), format(value), 100*float64(other-value)/float64(value)))
+		}
+	}
+	compare("power", "kW", powerWeight, vehicle.Power, candidate.Power)
+	if vehicle.MaximumMass != nil && candidate.MaximumMass != nil {
+		compare("maximum mass", "kg", massWeight, *vehicle.MaximumMass, *candidate.MaximumMass)
+	}
+	if vehicle.Seats != nil && candidate.Seats != nil {
+		compare("number of seats", "", seatsWeight, *vehicle.Seats, *candidate.Seats)
+	}
+
+	if vehicle.Bodywork != "" && candidate.Bodywork != "" {
+		weights += bodyworkWeight
+		if vehicle.Bodywork == candidate.Bodywork {
+			a.Explanations = append(a.Explanations, "same bodywork")
+		} else {
+			differences += bodyworkWeight
+			a.Explanations = append(a.Explanations, "different bodywork")
+		}
+	}
+	if vehicle.Category != "" {
+		a.Explanations = append(a.Explanations, "same vehicle category")
+	}
+
+	if weights > 0 {
+		a.Similarity = math.Round(1000*(1-differences/weights)) / 1000
+	}
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFindAlternatives(t *testing.T) {
+
+	vehicle := &Vehicle{ManufacturerID: "0005", TSN: "AEG", Category: "M1", Bodywork: "AE", PowerSourceID: 1, Power: 125, Seats: NullInt(4), MaximumMass: NullInt(1995)}
+	candidates := []*Vehicle{
+		{ManufacturerID: "0005", TSN: "BSI", Category: "M1", Bodywork: "AA", PowerSourceID: 4, Power: 75, Seats: NullInt(4), MaximumMass: NullInt(1730)},
+		{ManufacturerID: "0005", TSN: "PHV", Category: "M1", Bodywork: "AE", PowerSourceID: 25, Power: 135, Seats: NullInt(5), MaximumMass: NullInt(2100)},
+		{ManufacturerID: "0005", TSN: "VAN", Category: "M1", Bodywork: "AE", PowerSourceID: 4, Power: 125, Seats: NullInt(7), MaximumMass: NullInt(1995)},
+		{ManufacturerID: "0005", TSN: "N1E", Category: "N1", Bodywork: "AE", PowerSourceID: 4, Power: 125, Seats: NullInt(4), MaximumMass: NullInt(1995)},
+		{ManufacturerID: "0005", TSN: "DSL", Category: "M1", Bodywork: "AE", PowerSourceID: 2, Power: 125, Seats: NullInt(4), MaximumMass: NullInt(1995)},
+	}
+
+	powerSources := []*PowerSource{
+		{ID: 2, Classification: &PowerSourceClassification{FuelFamily: FuelFamilyDiesel, HybridType: HybridTypeNone}},
+		{ID: 4, Classification: &PowerSourceClassification{FuelFamily: FuelFamilyElectric, HybridType: HybridTypeNone}},
+		{ID: 25, Classification: &PowerSourceClassification{FuelFamily: FuelFamilyPlugInHybrid, HybridType: HybridTypePlugIn}},
+		{ID: 9999},
+	}
+
+	t.Log("classify alternative drives")
+	drives := alternativeDrives(powerSources)
+	if len(drives) != 2 || drives[4] != DriveElectric || drives[25] != DrivePlugInHybrid {
+		t.Fatalf("drives are bad, got:'%v'", drives)
+	}
+
+	t.Log("find alternatives")
+	alternatives := FindAlternatives(vehicle, candidates, drives, 10)
+
+	if len(alternatives) != 2 {
+		t.Fatalf("number of alternatives is bad, got:'%v', want:'%v'", len(alternatives), 2)
+	}
+	if a := alternatives[0]; a.Vehicle.TSN != "PHV" || a.Drive != DrivePlugInHybrid {
+		t.Fatalf("first alternative is bad, got:'%v', '%v'", a.Vehicle.TSN, a.Drive)
+	}
+	if a := alternatives[1]; a.Vehicle.TSN != "BSI" || a.Drive != DriveElectric {
+		t.Fatalf("second alternative is bad, got:'%v', '%v'", a.Vehicle.TSN, a.Drive)
+	}
+	if alternatives[0].Similarity <= alternatives[1].Similarity || alternatives[0].Similarity > 1 {
+		t.Fatalf("similarities are bad, got:'%v', '%v'", alternatives[0].Similarity, alternatives[1].Similarity)
+	}
+	want := []string{
+		"power 135 kW instead of 125 kW (+8%)",
+		"maximum mass 2100 kg instead of 1995 kg (+5%)",
+		"number of seats 5 instead of 4 (+25%)",
+		"same bodywork",
+		"same vehicle category",
+	}
+	if got := alternatives[0].Explanations; len(got) != len(want) {
+		t.Fatalf("explanations are bad, got:'%v', want:'%v'", got, want)
+	}
+	for i, explanation := range alternatives[0].Explanations {
+		if explanation != want[i] {
+			t.Fatalf("explanation is bad, got:'%v', want:'%v'", explanation, want[i])
+		}
+	}
+
+	t.Log("find limited alternatives")
+	if got := len(FindAlternatives(vehicle, candidates, drives, 1)); got != 1 {
+		t.Fatalf("number of alternatives is bad, got:'%v', want:'%v'", got, 1)
+	}
+}
+
+func TestServerGetAlternatives(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}/alternatives", service.GetAlternatives)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Log("get alternatives")
+	rr := get("/manufacturers/0005/vehicles/AEG/alternatives?limit=3")
+	AssertOkStatusCode(t, rr.Code)
+	var alternatives []*Alternative
+	if err := json.Unmarshal(rr.Body.Bytes(), &alternatives); err != nil {
+		t.Fatal(err)
+	}
+	if len(alternatives) == 0 || len(alternatives) > 3 {
+		t.Fatalf("number of alternatives is bad, got:'%v'", len(alternatives))
+	}
+	for i, a := range alternatives {
+		if a.Vehicle.Category != "M1" || a.Drive == "" || len(a.Vehicle.Links) != 1 {
+			t.Fatalf("alternative is bad, got:'%v'", rr.Body.String())
+		}
+		if i > 0 && a.Similarity > alternatives[i-1].Similarity {
+			t.Fatalf("alternatives are not ranked, got:'%v'", rr.Body.String())
+		}
+	}
+
+	t.Log("get alternatives with bad limit")
+	if rr := get("/manufacturers/0005/vehicles/AEG/alternatives?limit=0"); rr.Code != http.StatusBadRequest {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,642 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/enviroCar/vehicles/client"
+)
+
+// errUsage is returned by a command invoked with invalid arguments.
+var errUsage = errors.New("invalid usage")
+
+// Output formats of the command-line interface.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// lookupSource is the source of the data queried by the command-line
+// interface, either a running server or the local repository.
+type lookupSource interface {
+	io.Closer
+	Vehicle(hsn, tsn string) (*Vehicle, error)
+	Manufacturers() ([]*Manufacturer, error)
+	SearchVehicles(filter *VehicleFilter) ([]*Vehicle, error)
+	PowerSources() ([]*PowerSource, error)
+}
+
+// cli is the command-line interface.
+type cli struct {
+	stdout    io.Writer
+	stderr    io.Writer
+	newSource func(url, language string) (lookupSource, error)
+
+	configFile string
+	url        string
+	language   string
+	format     string
+}
+
+// cliCommands are the subcommands of the command-line interface.
+var cliCommands = map[string]struct {
+	description string
+	run         func(c *cli, args []string) error
+}{
+	"lookup":        {"look up a vehicle by its HSN and TSN", (*cli).lookup},
+	"manufacturers": {"list manufacturers", (*cli).manufacturers},
+	"search":        {"search vehicles by name", (*cli).search},
+	"power-sources": {"list power sources", (*cli).powerSources},
+	"migrate":       {"apply pending database migrations or show their status", (*cli).migrate},
+	"config":        {"check the configuration", (*cli).config},
+	"healthcheck":   {"check the health of the running server", (*cli).healthcheck},
+}
+
+// runCLI runs the subcommand with the arguments and returns the exit code.
+func runCLI(name string, args []string, stdout, stderr io.Writer) int {
+	c := &cli{stdout: stdout, stderr: stderr}
+	c.newSource = c.newLookupSource
+	return c.run(name, args)
+}
+
+func (c *cli) run(name string, args []string) int {
+	command, ok := cliCommands[name]
+	if !ok {
+		c.usage()
+		return 2
+	}
+	switch err := command.run(c, args); {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintf(c.stderr, "error: %v\n", err)
+		return 1
+	}
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "usage: vehicles [serve | <command> [flags] [args]]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(c.stderr, "  %-15s %s\n", name, cliCommands[name].description)
+	}
+	fmt.Fprintf(c.stderr, "  %-15s %s\n", "serve", "run the server (default)")
+}
+
+// newFlagSet creates the flag set of a subcommand with the output format flag.
+func (c *cli) newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.StringVar(&c.format, "format", FormatTable, "output format: table, json or csv")
+	fs.StringVar(&c.configFile, "config", os.Getenv("CONFIG_FILE"), "configuration file (YAML) of the local store")
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: vehicles %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// flagSet creates the flag set of a lookup subcommand with the common flags.
+func (c *cli) flagSet(name, usage string) *flag.FlagSet {
+	fs := c.newFlagSet(name, usage)
+	fs.StringVar(&c.url, "url", os.Getenv("VEHICLES_URL"), "base URL of a running server, the local store is used if empty")
+	fs.StringVar(&c.language, "lang", DefaultLanguage, "language of texts")
+	return fs
+}
+
+// parse parses the arguments and checks the number of positional arguments.
+func (c *cli) parse(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		fs.Usage()
+		return errUsage
+	}
+	switch c.format {
+	case FormatTable, FormatJSON, FormatCSV:
+	default:
+		fmt.Fprintf(c.stderr, "unknown format: '%s'\n", c.format)
+		return errUsage
+	}
+	return nil
+}
+
+// withSource runs f with the source selected by the flags.
+func (c *cli) withSource(f func(source lookupSource) error) error {
+	source, err := c.newSource(c.url, c.language)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	return f(source)
+}
+
+func (c *cli) lookup(args []string) error {
+	fs := c.flagSet("lookup", "[flags] HSN TSN")
+	if err := c.parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	return c.withSource(func(source lookupSource) error {
+		v, err := source.Vehicle(normalizeHSN(fs.Arg(0)), normalizeTSN(fs.Arg(1)))
+		if err != nil {
+			return err
+		}
+		details := &vehicleDetails{
+			Vehicle:      v,
+			Manufacturer: v.Manufacturer,
+			PowerSource:  v.PowerSource,
+		}
+		return c.write(details, vehicleDetailsTable(v), true)
+	})
+}
+
+func (c *cli) manufacturers(args []string) error {
+	fs := c.flagSet("manufacturers", "[flags]")
+	name := fs.String("name", "", "only list manufacturers whose name contains this text")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	return c.withSource(func(source lookupSource) error {
+		entities, err := source.Manufacturers()
+		if err != nil {
+			return err
+		}
+		manufacturers := []*Manufacturer{}
+		t := &table{header: []string{"HSN", "NAME"}}
+		for _, m := range entities {
+			if !strings.Contains(strings.ToLower(m.Name), strings.ToLower(*name)) {
+				continue
+			}
+			manufacturers = append(manufacturers, m)
+			t.rows = append(t.rows, []string{m.ID, m.Name})
+		}
+		return c.write(manufacturers, t, false)
+	})
+}
+
+func (c *cli) search(args []string) error {
+	fs := c.flagSet("search", "[flags] TEXT")
+	filter := &VehicleFilter{}
+	fs.StringVar(&filter.ManufacturerID, "hsn", "", "only search vehicles of the manufacturer")
+	powerSource := fs.Int("power-source", 0, "only search vehicles with the power source")
+	fs.StringVar(&filter.FuelFamily, "fuel-family", "", "only search vehicles of the fuel family, e.g. 'plug-in-hybrid'")
+	fs.IntVar(&filter.Limit, "limit", 50, "maximum number of vehicles")
+	fs.IntVar(&filter.Offset, "offset", 0, "number of vehicles to skip")
+	if err := c.parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	filter.Query = strings.Join(fs.Args(), " ")
+	if *powerSource != 0 {
+		filter.PowerSourceID = powerSource
+	}
+	if filter.FuelFamily != "" && !containsString(FuelFamilies, filter.FuelFamily) {
+		return fmt.Errorf("fuel family must be one of %s: '%s'", strings.Join(FuelFamilies, ", "), filter.FuelFamily)
+	}
+	return c.withSource(func(source lookupSource) error {
+		vehicles, err := source.SearchVehicles(filter)
+		if err != nil {
+			return err
+		}
+		t := &table{header: []string{"HSN", "TSN", "MANUFACTURER", "TRADE NAME", "COMMERCIAL NAME", "ALLOTMENT DATE", "POWER (KW)"}}
+		for _, v := range vehicles {
+			t.rows = append(t.rows, []string{
+				v.ManufacturerID,
+				v.TSN,
+				v.ManufacturerName,
+				v.TradeName,
+				v.CommercialName,
+				v.AllotmentDate.String(),
+				formatInt(v.Power),
+			})
+		}
+		if vehicles == nil {
+			vehicles = []*Vehicle{}
+		}
+		return c.write(vehicles, t, false)
+	})
+}
+
+func (c *cli) powerSources(args []string) error {
+	fs := c.flagSet("power-sources", "[flags]")
+	if err := c.parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	return c.withSource(func(source lookupSource) error {
+		powerSources, err := source.PowerSources()
+		if err != nil {
+			return err
+		}
+		t := &table{header: []string{"ID", "NAME", "FUEL FAMILY", "DESCRIPTION"}}
+		for _, ps := range powerSources {
+			fuelFamily := ""
+			if ps.Classification != nil {
+				fuelFamily = ps.Classification.FuelFamily
+			}
+			t.rows = append(t.rows, []string{strconv.Itoa(ps.ID), ps.ShortName, fuelFamily, ps.Description})
+		}
+		return c.write(powerSources, t, false)
+	})
+}
+
+func (c *cli) migrate(args []string) error {
+	fs := c.newFlagSet("migrate", "[flags] [status]")
+	if err := c.parse(fs, args, 0, 1); err != nil {
+		return err
+	}
+	if fs.NArg() == 1 && fs.Arg(0) != "status" {
+		fs.Usage()
+		return errUsage
+	}
+	config, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+	if config.Database.Store != StorePostgres {
+		return fmt.Errorf("migrations require the postgres store, not '%s'", config.Database.Store)
+	}
+	repository, err := newRepository(&config.Database)
+	if err != nil {
+		return err
+	}
+	defer repository.Close()
+	migrator, err := repository.(*PostgresRepository).Migrator()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		if _, err := migrator.Migrate(); err != nil {
+			return err
+		}
+	}
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"VERSION", "NAME", "APPLIED AT"}}
+	for _, s := range status {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		t.rows = append(t.rows, []string{fmt.Sprintf("%04d", s.Version), s.Name, appliedAt})
+	}
+	return c.write(status, t, false)
+}
+
+// vehicleDetails is the JSON output of a looked up vehicle.
+type vehicleDetails struct {
+	*Vehicle
+	Manufacturer *Manufacturer `json:"manufacturer,omitempty"`
+	PowerSource  *PowerSource  `json:"powerSource,omitempty"`
+}
+
+func vehicleDetailsTable(v *Vehicle) *table {
+	manufacturer := v.ManufacturerName
+	if manufacturer == "" && v.Manufacturer != nil {
+		manufacturer = v.Manufacturer.Name
+	}
+	powerSource := ""
+	if v.PowerSource != nil {
+		powerSource = v.PowerSource.ShortName
+	}
+	return &table{
+		header: []string{
+			"HSN", "TSN", "MANUFACTURER", "TRADE NAME", "COMMERCIAL NAME",
+			"ALLOTMENT DATE", "CATEGORY", "BODYWORK", "POWER SOURCE",
+			"POWER (KW)", "ENGINE CAPACITY (CCM)", "AXLES", "POWERED AXLES",
+			"SEATS", "MAXIMUM MASS (KG)",
+		},
+		rows: [][]string{{
+			v.ManufacturerID, v.TSN, manufacturer, v.TradeName, v.CommercialName,
+			v.AllotmentDate.String(), v.Category, v.Bodywork, powerSource,
+			formatInt(v.Power), formatNullInt(v.EngineCapacity), formatNullInt(v.Axles), formatNullInt(v.PoweredAxles),
+			formatNullInt(v.Seats), formatNullInt(v.MaximumMass),
+		}},
+	}
+}
+
+// formatInt formats the value, leaving unknown zero values empty.
+func formatInt(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+// formatNullInt formats the value, leaving unknown values empty.
+func formatNullInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+// table is the tabular output of a command.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// transpose returns the table of a single record as field and value rows.
+func (t *table) transpose() *table {
+	transposed := &table{header: []string{"FIELD", "VALUE"}}
+	for i, field := range t.header {
+		transposed.rows = append(transposed.rows, []string{field, t.rows[0][i]})
+	}
+	return transposed
+}
+
+// write writes v or its table in the output format. A single record is
+// written as field and value rows in the table format.
+func (c *cli) write(v interface{}, t *table, single bool) error {
+	switch c.format {
+	case FormatJSON:
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case FormatCSV:
+		w := csv.NewWriter(c.stdout)
+		w.Write(t.header)
+		w.WriteAll(t.rows)
+		return w.Error()
+	default:
+		if single {
+			t = t.transpose()
+		}
+		w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// loadConfig loads the configuration of the local store from the
+// environment and the configuration file.
+func (c *cli) loadConfig() (*Config, error) {
+	var args []string
+	if c.configFile != "" {
+		args = []string{"-config", c.configFile}
+	}
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return LoadConfig(fs, args, os.LookupEnv)
+}
+
+// config validates the configuration given by the flags of the server, the
+// environment and the configuration file and writes the effective
+// configuration.
+func (c *cli) config(args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(c.stderr, "usage: vehicles config check [server flags]")
+		return errUsage
+	}
+	fs := flag.NewFlagSet("config check", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	config, err := LoadConfig(fs, args[1:], os.LookupEnv)
+	var errs ConfigErrors
+	if errors.As(err, &errs) {
+		for _, err := range errs {
+			fmt.Fprintln(c.stderr, err)
+		}
+		return errors.New("configuration is invalid")
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(c.stdout, config)
+	return nil
+}
+
+// healthcheck requests the health check of the server running with the
+// configuration given by the flags of the server, the environment and the
+// configuration file.
+func (c *cli) healthcheck(args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	config, err := LoadConfig(fs, args, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	url, err := healthURL(config.Server.HealthAddress)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return checkHealth(ctx, url)
+}
+
+// newLookupSource creates a source querying the server at url or, if url is
+// empty, the local repository configured by the environment and the
+// configuration file.
+func (c *cli) newLookupSource(url, language string) (lookupSource, error) {
+	if url == "" {
+		if !isSupportedLanguage(language) {
+			return nil, fmt.Errorf("unsupported language: '%s'", language)
+		}
+		config, err := c.loadConfig()
+		if err != nil {
+			return nil, err
+		}
+		repository, err := newRepository(&config.Database)
+		if err != nil {
+			return nil, err
+		}
+		return &repositorySource{repository, language}, nil
+	}
+	vc, err := client.New(url, client.WithLanguage(language))
+	if err != nil {
+		return nil, err
+	}
+	return &clientSource{vc, context.Background()}, nil
+}
+
+// repositorySource is a lookupSource querying the local repository.
+type repositorySource struct {
+	repository Repository
+	language   string
+}
+
+func (s *repositorySource) Close() error {
+	return s.repository.Close()
+}
+
+func (s *repositorySource) Vehicle(hsn, tsn string) (*Vehicle, error) {
+	m, err := s.repository.GetManufacturer(hsn)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.GetVehicle(m, tsn, s.language)
+}
+
+func (s *repositorySource) Manufacturers() ([]*Manufacturer, error) {
+	return s.repository.GetManufacturers()
+}
+
+func (s *repositorySource) SearchVehicles(filter *VehicleFilter) ([]*Vehicle, error) {
+	return s.repository.FindVehicles(filter)
+}
+
+func (s *repositorySource) PowerSources() ([]*PowerSource, error) {
+	return s.repository.GetPowerSources(s.language)
+}
+
+// clientSource is a lookupSource querying a running server.
+type clientSource struct {
+	client *client.Client
+	ctx    context.Context
+}
+
+func (s *clientSource) Close() error {
+	return nil
+}
+
+func (s *clientSource) Vehicle(hsn, tsn string) (*Vehicle, error) {
+	v, err := s.client.Vehicle(s.ctx, hsn, tsn)
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.client.FollowManufacturer(s.ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := s.client.FollowPowerSource(s.ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	vehicle := vehicleFromClient(v)
+	vehicle.ManufacturerID = m.HSN
+	vehicle.Manufacturer = manufacturerFromClient(m)
+	vehicle.PowerSourceID = ps.ID
+	vehicle.PowerSource = powerSourceFromClient(ps)
+	return vehicle, nil
+}
+
+func (s *clientSource) Manufacturers() ([]*Manufacturer, error) {
+	entities, err := s.client.Manufacturers(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	manufacturers := make([]*Manufacturer, len(entities))
+	for i, m := range entities {
+		manufacturers[i] = manufacturerFromClient(m)
+	}
+	return manufacturers, nil
+}
+
+func (s *clientSource) SearchVehicles(filter *VehicleFilter) ([]*Vehicle, error) {
+	options := client.SearchOptions{
+		Query:      filter.Query,
+		HSN:        filter.ManufacturerID,
+		FuelFamily: filter.FuelFamily,
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}
+	if filter.PowerSourceID != nil {
+		options.PowerSourceID = *filter.PowerSourceID
+	}
+	entities, err := s.client.Search(s.ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	vehicles := make([]*Vehicle, len(entities))
+	for i, v := range entities {
+		vehicles[i] = vehicleFromClient(v)
+	}
+	return vehicles, nil
+}
+
+func (s *clientSource) PowerSources() ([]*PowerSource, error) {
+	entities, err := s.client.PowerSources(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+	powerSources := make([]*PowerSource, len(entities))
+	for i, ps := range entities {
+		powerSources[i] = powerSourceFromClient(ps)
+	}
+	return powerSources, nil
+}
+
+func manufacturerFromClient(m *client.Manufacturer) *Manufacturer {
+	manufacturer := &Manufacturer{ID: m.HSN, Name: m.Name}
+	for _, name := range m.Names {
+		manufacturer.Names = append(manufacturer.Names, &ManufacturerName{
+			ManufacturerID: m.HSN,
+			Name:           name.Name,
+			ValidFrom:      clientDate(name.From),
+			ValidTo:        clientDate(name.To),
+		})
+	}
+	return manufacturer
+}
+
+func vehicleFromClient(v *client.Vehicle) *Vehicle {
+	return &Vehicle{
+		ManufacturerID:   v.HSN,
+		TSN:              v.TSN,
+		ManufacturerName: v.ManufacturerName,
+		TradeName:        v.TradeName,
+		CommercialName:   v.CommercialName,
+		AllotmentDate:    clientDate(v.AllotmentDate),
+		Category:         v.Category,
+		Bodywork:         v.Bodywork,
+		Power:            v.Power,
+		EngineCapacity:   v.EngineCapacity,
+		Axles:            v.Axles,
+		PoweredAxles:     v.PoweredAxles,
+		Seats:            v.Seats,
+		MaximumMass:      v.MaximumMass,
+	}
+}
+
+// clientDate parses a date of the client, malformed dates being unknown.
+func clientDate(value string) Date {
+	date, _ := ParseDate(value)
+	return date
+}
+
+func powerSourceFromClient(ps *client.PowerSource) *PowerSource {
+	powerSource := &PowerSource{
+		ID:          ps.ID,
+		ShortName:   ps.Name,
+		Description: ps.Description,
+	}
+	if c := ps.Classification; c != nil {
+		powerSource.Classification = &PowerSourceClassification{
+			PowerSourceID:  ps.ID,
+			FuelFamily:     c.FuelFamily,
+			EnergyCarriers: c.EnergyCarriers,
+			Electrified:    c.Electrified,
+			HybridType:     c.HybridType,
+			FuelCell:       c.FuelCell,
+			Bivalent:       c.Bivalent,
+		}
+	}
+	return powerSource
+}
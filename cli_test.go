@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLookupSource struct{ language string }
+
+func (s *testLookupSource) Close() error { return nil }
+
+func (s *testLookupSource) Vehicle(hsn, tsn string) (*Vehicle, error) {
+	if hsn != "0005" || tsn != "155" {
+		return nil, ErrNotFound
+	}
+	return &Vehicle{
+		ManufacturerID:   "0005",
+		Manufacturer:     &Manufacturer{ID: "0005", Name: "BMW"},
+		PowerSourceID:    1,
+		PowerSource:      &PowerSource{ID: 1, ShortName: "Benzin"},
+		TSN:              "155",
+		ManufacturerName: "BMW",
+		CommercialName:   "645CI",
+		Power:            245,
+	}, nil
+}
+
+func (s *testLookupSource) Manufacturers() ([]*Manufacturer, error) {
+	return []*Manufacturer{{ID: "0005", Name: "BMW"}, {ID: "0035", Name: "OPEL, ADAM"}}, nil
+}
+
+func (s *testLookupSource) SearchVehicles(filter *VehicleFilter) ([]*Vehicle, error) {
+	if filter.Query != "645 CI" || filter.Limit != 5 {
+		return nil, nil
+	}
+	return []*Vehicle{{
+		ManufacturerID:   "0005",
+		TSN:              "155",
+		ManufacturerName: "BMW",
+		TradeName:        "6ER",
+		CommercialName:   "645CI",
+		AllotmentDate:    NewDate(2003, time.July, 1),
+		Power:            245,
+	}}, nil
+}
+
+func (s *testLookupSource) PowerSources() ([]*PowerSource, error) {
+	return []*PowerSource{{ID: 1, ShortName: "Petrol", Description: "Petrol"}}, nil
+}
+
+func runTestCLI(t *testing.T, name string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	c := &cli{
+		stdout: &stdout,
+		stderr: &stderr,
+		newSource: func(url, language string) (lookupSource, error) {
+			return &testLookupSource{language}, nil
+		},
+	}
+	code := c.run(name, args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLILookup(t *testing.T) {
+
+	t.Log("lookup as table")
+	code, stdout, _ := runTestCLI(t, "lookup", "0005", "155")
+	if code != 0 {
+		t.Fatalf("exit code is bad, got:'%v', want:'%v'", code, 0)
+	}
+	for _, want := range []string{"MANUFACTURER           BMW\n", "POWER SOURCE           Benzin\n", "POWER (KW)             245\n"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("output is bad, got:'%v', want:'%v'", stdout, want)
+		}
+	}
+
+	t.Log("lookup as json")
+	_, stdout, _ = runTestCLI(t, "lookup", "-format", "json", "0005", "155")
+	if !strings.Contains(stdout, `"powerSource": {`) || !strings.Contains(stdout, `"hsn": "0005"`) {
+		t.Fatalf("output is bad, got:'%v'", stdout)
+	}
+
+	t.Log("lookup unknown vehicle")
+	code, _, stderr := runTestCLI(t, "lookup", "0005", "000")
+	if code != 1 || stderr != "error: not found\n" {
+		t.Fatalf("result is bad, got:'%v' '%v'", code, stderr)
+	}
+
+	t.Log("lookup without tsn")
+	if code, _, _ := runTestCLI(t, "lookup", "0005"); code != 2 {
+		t.Fatalf("exit code is bad, got:'%v', want:'%v'", code, 2)
+	}
+}
+
+func TestCLIManufacturers(t *testing.T) {
+
+	t.Log("list manufacturers as csv")
+	_, stdout, _ := runTestCLI(t, "manufacturers", "-format", "csv", "-name", "opel")
+	want := "HSN,NAME\n0035,\"OPEL, ADAM\"\n"
+	if stdout != want {
+		t.Fatalf("output is bad, got:'%v', want:'%v'", stdout, want)
+	}
+}
+
+func TestCLISearch(t *testing.T) {
+
+	t.Log("search vehicles as table")
+	_, stdout, _ := runTestCLI(t, "search", "-limit", "5", "645", "CI")
+	want := "HSN   TSN  MANUFACTURER  TRADE NAME  COMMERCIAL NAME  ALLOTMENT DATE  POWER (KW)\n" +
+		"0005  155  BMW           6ER         645CI            2003-07-01      245\n"
+	if stdout != want {
+		t.Fatalf("output is bad, got:'%v', want:'%v'", stdout, want)
+	}
+
+	t.Log("search vehicles without results as json")
+	_, stdout, _ = runTestCLI(t, "search", "-format", "json", "unknown")
+	if stdout != "[]\n" {
+		t.Fatalf("output is bad, got:'%v', want:'%v'", stdout, "[]\n")
+	}
+}
+
+func TestCLIUsage(t *testing.T) {
+
+	t.Log("run unknown command")
+	if code, _, _ := runTestCLI(t, "unknown"); code != 2 {
+		t.Fatalf("exit code is bad, got:'%v', want:'%v'", code, 2)
+	}
+
+	t.Log("run with unknown format")
+	if code, _, _ := runTestCLI(t, "power-sources", "-format", "xml"); code != 2 {
+		t.Fatalf("exit code is bad, got:'%v', want:'%v'", code, 2)
+	}
+}
@@ -0,0 +1,393 @@
+// Package client is a Go client of the enviroCar vehicle service that follows
+// the hypermedia links of its resources.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a single request.
+	DefaultTimeout = 10 * time.Second
+	// DefaultRetries is the default number of retries of a failed request.
+	DefaultRetries = 2
+	// DefaultBackoff is the default delay before the first retry, doubling
+	// with every further retry.
+	DefaultBackoff = 200 * time.Millisecond
+)
+
+// Client is a client of the vehicle service.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	timeout    time.Duration
+	retries    int
+	backoff    time.Duration
+	language   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithTimeout sets the timeout of a single request, zero for none. It applies
+// to the context of each request, whatever HTTP client is used.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) { c.timeout = timeout }
+}
+
+// WithRetries sets the number of retries of failed requests and the delay
+// before the first retry.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// WithLanguage sets the preferred language of texts.
+func WithLanguage(language string) Option {
+	return func(c *Client) { c.language = language }
+}
+
+// New creates a new Client for the service at baseURL, e.g.
+// 'https://processing.envirocar.org/vehicles'.
+func New(baseURL string, options ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+	if !u.IsAbs() {
+		return nil, fmt.Errorf("base url is not absolute: '%s'", baseURL)
+	}
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		timeout:    DefaultTimeout,
+		retries:    DefaultRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c, nil
+}
+
+// resolve resolves the path relative to the base URL, escaping the segments
+// substituted into the path.
+func (c *Client) resolve(path string, segments ...string) *url.URL {
+	raw := make([]interface{}, len(segments))
+	escaped := make([]interface{}, len(segments))
+	for i, segment := range segments {
+		raw[i] = segment
+		escaped[i] = url.PathEscape(segment)
+	}
+	return c.baseURL.ResolveReference(&url.URL{
+		Path:    fmt.Sprintf(path, raw...),
+		RawPath: fmt.Sprintf(path, escaped...),
+	})
+}
+
+// Get gets the resource at the URL and decodes it into v, retrying on network
+// errors and temporary server errors.
+func (c *Client) Get(ctx context.Context, u *url.URL, v interface{}) error {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := c.get(ctx, u, v)
+		if err == nil || !retry || attempt >= c.retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) get(ctx context.Context, u *url.URL, v interface{}) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	reqCtx := ctx
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req = req.WithContext(reqCtx)
+	req.Header.Set("Accept", "application/json")
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return true, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return retryable(res.StatusCode), decodeError(res)
+	}
+	if res.StatusCode == http.StatusNoContent || v == nil {
+		_, err = io.Copy(ioutil.Discard, res.Body)
+		return false, err
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("could not decode response of '%s': %v", u, err)
+	}
+	return false, nil
+}
+
+func decodeError(res *http.Response) error {
+	e := &Error{}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil || json.Unmarshal(body, e) != nil || e.StatusCode == 0 {
+		e.StatusCode = res.StatusCode
+		e.StatusText = http.StatusText(res.StatusCode)
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+// Follow gets the resource linked from the resource by the relation and
+// decodes it into v.
+func (c *Client) Follow(ctx context.Context, from Resource, relation string, v interface{}) error {
+	link := from.Link(relation)
+	if link == nil || link.URL == nil {
+		return fmt.Errorf("%w: '%s'", ErrNoLink, relation)
+	}
+	return c.Get(ctx, c.baseURL.ResolveReference(link.URL), v)
+}
+
+// Root gets the entry point of the service.
+func (c *Client) Root(ctx context.Context) (*Root, error) {
+	root := &Root{}
+	if err := c.Get(ctx, c.baseURL, root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// Manufacturers gets all manufacturers.
+func (c *Client) Manufacturers(ctx context.Context) ([]*Manufacturer, error) {
+	var manufacturers []*Manufacturer
+	if err := c.Get(ctx, c.resolve("manufacturers"), &manufacturers); err != nil {
+		return nil, err
+	}
+	return manufacturers, nil
+}
+
+// Manufacturer gets the manufacturer by its HSN.
+func (c *Client) Manufacturer(ctx context.Context, hsn string) (*Manufacturer, error) {
+	m := &Manufacturer{}
+	if err := c.Get(ctx, c.resolve("manufacturers/%s", hsn), m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Vehicles gets all vehicles of the manufacturer by its HSN.
+func (c *Client) Vehicles(ctx context.Context, hsn string) ([]*Vehicle, error) {
+	var vehicles []*Vehicle
+	if err := c.Get(ctx, c.resolve("manufacturers/%s/vehicles", hsn), &vehicles); err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+// ListOptions are the representation of the vehicles of a listing. Zero
+// values are not applied.
+type ListOptions struct {
+	// View is "summary" or "full".
+	View string
+	// Fields are the fields of the vehicles, e.g. "tsn" and "power".
+	Fields []string
+	// Embed are the related objects inlined into each vehicle, i.e.
+	// "manufacturer" and "powerSource".
+	Embed []string
+}
+
+func (o *ListOptions) apply(query url.Values) {
+	if o.View != "" {
+		query.Set("view", o.View)
+	}
+	if len(o.Fields) > 0 {
+		query.Set("fields", strings.Join(o.Fields, ","))
+	}
+	if len(o.Embed) > 0 {
+		query.Set("embed", strings.Join(o.Embed, ","))
+	}
+}
+
+// ListVehicles gets all vehicles of the manufacturer by its HSN as specified
+// by the options.
+func (c *Client) ListVehicles(ctx context.Context, hsn string, options ListOptions) ([]*Vehicle, error) {
+	query := url.Values{}
+	options.apply(query)
+	u := c.resolve("manufacturers/%s/vehicles", hsn)
+	u.RawQuery = query.Encode()
+
+	var vehicles []*Vehicle
+	if err := c.Get(ctx, u, &vehicles); err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+// Vehicle gets the vehicle by its HSN and TSN.
+func (c *Client) Vehicle(ctx context.Context, hsn, tsn string) (*Vehicle, error) {
+	v := &Vehicle{}
+	if err := c.Get(ctx, c.resolve("manufacturers/%s/vehicles/%s", hsn, tsn), v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// SearchOptions restricts the vehicles returned by Search. Zero values are
+// not applied.
+type SearchOptions struct {
+	// Query matches any part of the trade, commercial or manufacturer name.
+	Query         string
+	HSN           string
+	PowerSourceID int
+	// FuelFamily is the fuel family of the power source, e.g. "electric".
+	FuelFamily string
+	Limit      int
+	Offset     int
+	ListOptions
+}
+
+// Search searches vehicles across all manufacturers.
+func (c *Client) Search(ctx context.Context, options SearchOptions) ([]*Vehicle, error) {
+	query := url.Values{}
+	if options.Query != "" {
+		query.Set("q", options.Query)
+	}
+	if options.HSN != "" {
+		query.Set("hsn", options.HSN)
+	}
+	if options.PowerSourceID != 0 {
+		query.Set("powerSource", strconv.Itoa(options.PowerSourceID))
+	}
+	if options.FuelFamily != "" {
+		query.Set("fuelFamily", options.FuelFamily)
+	}
+	if options.Limit != 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+	if options.Offset != 0 {
+		query.Set("offset", strconv.Itoa(options.Offset))
+	}
+	options.ListOptions.apply(query)
+	u := c.resolve("vehicles")
+	u.RawQuery = query.Encode()
+
+	var vehicles []*Vehicle
+	if err := c.Get(ctx, u, &vehicles); err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+// PowerSources gets all power sources.
+func (c *Client) PowerSources(ctx context.Context) ([]*PowerSource, error) {
+	var powerSources []*PowerSource
+	if err := c.Get(ctx, c.resolve("powerSources"), &powerSources); err != nil {
+		return nil, err
+	}
+	return powerSources, nil
+}
+
+// PowerSource gets the power source by its code.
+func (c *Client) PowerSource(ctx context.Context, id int) (*PowerSource, error) {
+	ps := &PowerSource{}
+	if err := c.Get(ctx, c.resolve("powerSources/%s", strconv.Itoa(id)), ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// VIN decodes the VIN and gets the candidate manufacturers.
+func (c *Client) VIN(ctx context.Context, vin string) (*VIN, error) {
+	v := &VIN{}
+	if err := c.Get(ctx, c.resolve("vins/%s", vin), v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// FollowManufacturers follows the 'manufacturers' link of the root or of a
+// power source.
+func (c *Client) FollowManufacturers(ctx context.Context, from Resource) ([]*Manufacturer, error) {
+	var manufacturers []*Manufacturer
+	if err := c.Follow(ctx, from, RelManufacturers, &manufacturers); err != nil {
+		return nil, err
+	}
+	return manufacturers, nil
+}
+
+// FollowPowerSources follows the 'powerSources' link of the root.
+func (c *Client) FollowPowerSources(ctx context.Context, root *Root) ([]*PowerSource, error) {
+	var powerSources []*PowerSource
+	if err := c.Follow(ctx, root, RelPowerSources, &powerSources); err != nil {
+		return nil, err
+	}
+	return powerSources, nil
+}
+
+// FollowVehicles follows the 'vehicles' link of the manufacturer or of a
+// power source.
+func (c *Client) FollowVehicles(ctx context.Context, from Resource) ([]*Vehicle, error) {
+	var vehicles []*Vehicle
+	if err := c.Follow(ctx, from, RelVehicles, &vehicles); err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+// FollowManufacturer follows the 'manufacturer' link of the resource.
+func (c *Client) FollowManufacturer(ctx context.Context, from Resource) (*Manufacturer, error) {
+	m := &Manufacturer{}
+	if err := c.Follow(ctx, from, RelManufacturer, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FollowPowerSource follows the 'powerSource' link of the resource.
+func (c *Client) FollowPowerSource(ctx context.Context, from Resource) (*PowerSource, error) {
+	ps := &PowerSource{}
+	if err := c.Follow(ctx, from, RelPowerSource, ps); err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// FollowSelf gets the complete representation of the resource by its 'self'
+// link or, for list items, its 'canonical' link and decodes it into v.
+func (c *Client) FollowSelf(ctx context.Context, from Resource, v interface{}) error {
+	if from.Link(RelSelf) != nil {
+		return c.Follow(ctx, from, RelSelf, v)
+	}
+	return c.Follow(ctx, from, RelCanonical, v)
+}
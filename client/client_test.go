@@ -0,0 +1,243 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func NewTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	respond := func(path string, status int, body func() string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(body()))
+		})
+	}
+	respond("/vehicles/", http.StatusOK, func() string {
+		return `{"links":[{"href":"` + server.URL + `/vehicles/manufacturers","type":"application/json","title":"Manufacturers","rel":"manufacturers"}]}`
+	})
+	respond("/vehicles/manufacturers", http.StatusOK, func() string {
+		return `[{"links":[{"href":"` + server.URL + `/vehicles/manufacturers/0005","type":"application/json","title":"BMW","rel":"canonical"}],"hsn":"0005","name":"BMW"}]`
+	})
+	respond("/vehicles/manufacturers/0005", http.StatusOK, func() string {
+		return `{"links":[{"href":"` + server.URL + `/vehicles/manufacturers/0005/vehicles","type":"application/json","rel":"vehicles"}],"hsn":"0005","name":"BMW","names":[{"name":"BMW","from":"1949-11-01","to":"2019-07-15"}]}`
+	})
+	respond("/vehicles/manufacturers/0005/vehicles", http.StatusOK, func() string {
+		return `[{"links":[{"href":"/vehicles/manufacturers/0005/vehicles/155","type":"application/json","title":"645CI","rel":"canonical"}],"hsn":"0005","tsn":"155","commercialName":"645CI"}]`
+	})
+	respond("/vehicles/manufacturers/0005/vehicles/155", http.StatusOK, func() string {
+		return `{"links":[{"href":"` + server.URL + `/vehicles/powerSources/1","type":"application/json","title":"Benzin","rel":"powerSource"}],"hsn":"0005","tsn":"155","commercialName":"645CI","power":245}`
+	})
+	mux.HandleFunc("/vehicles/vehicles", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "645" || r.URL.Query().Get("limit") != "5" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("embed") == "manufacturer,powerSource" {
+			w.Write([]byte(`[{"hsn":"0005","tsn":"155","manufacturer":{"hsn":"0005","name":"BMW"},"powerSource":{"id":1,"name":"Benzin"}}]`))
+			return
+		}
+		w.Write([]byte(`[{"hsn":"0005","tsn":"155","commercialName":"645CI"}]`))
+	})
+	respond("/vehicles/powerSources/1", http.StatusOK, func() string {
+		return `{"links":[{"href":"` + server.URL + `/vehicles/powerSources/1/vehicles","type":"application/json","rel":"vehicles"}],"id":1,"name":"Benzin","description":"Benzin"}`
+	})
+	respond("/vehicles/powerSources/1/vehicles", http.StatusOK, func() string {
+		return `[{"hsn":"0005","tsn":"155","commercialName":"645CI"}]`
+	})
+	respond("/vehicles/manufacturers/000x", http.StatusNotFound, func() string {
+		return `{"statusCode":404,"statusText":"Not Found","message":"not found"}`
+	})
+	attempts := 0
+	mux.HandleFunc("/vehicles/flaky", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"links":[]}`))
+	})
+	mux.HandleFunc("/vehicles/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte(`{"links":[]}`))
+	})
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestClientNavigation(t *testing.T) {
+
+	server := NewTestServer(t)
+	defer server.Close()
+
+	c, err := New(server.URL + "/vehicles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	t.Log("get root")
+	root, err := c.Root(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("follow manufacturers")
+	manufacturers, err := c.FollowManufacturers(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manufacturers) != 1 || manufacturers[0].HSN != "0005" {
+		t.Fatalf("manufacturers are bad, got:'%v'", manufacturers)
+	}
+
+	t.Log("follow canonical manufacturer")
+	m := &Manufacturer{}
+	if err := c.FollowSelf(ctx, manufacturers[0], m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Names) != 1 || m.Names[0].From != "1949-11-01" {
+		t.Fatalf("manufacturer names are bad, got:'%v'", m.Names)
+	}
+
+	t.Log("follow vehicles")
+	vehicles, err := c.FollowVehicles(ctx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("follow relative canonical vehicle link")
+	v := &Vehicle{}
+	if err := c.FollowSelf(ctx, vehicles[0], v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Power != 245 {
+		t.Fatalf("power is bad, got:'%v', want:'%v'", v.Power, 245)
+	}
+
+	t.Log("follow power source")
+	ps, err := c.FollowPowerSource(ctx, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Name != "Benzin" {
+		t.Fatalf("power source is bad, got:'%v', want:'%v'", ps.Name, "Benzin")
+	}
+
+	t.Log("follow vehicles of power source")
+	vehicles, err = c.FollowVehicles(ctx, ps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) != 1 || vehicles[0].TSN != "155" {
+		t.Fatalf("vehicles are bad, got:'%v'", vehicles)
+	}
+
+	t.Log("follow missing manufacturer link")
+	if _, err := c.FollowManufacturer(ctx, ps); !errors.Is(err, ErrNoLink) {
+		t.Fatalf("error is bad, got:'%v', want:'%v'", err, ErrNoLink)
+	}
+}
+
+func TestClientSearch(t *testing.T) {
+
+	server := NewTestServer(t)
+	defer server.Close()
+
+	c, err := New(server.URL + "/vehicles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("search vehicles")
+	vehicles, err := c.Search(context.Background(), SearchOptions{Query: "645", Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) != 1 || vehicles[0].HSN != "0005" {
+		t.Fatalf("vehicles are bad, got:'%v'", vehicles)
+	}
+
+	t.Log("search vehicles with embedded manufacturer and power source")
+	vehicles, err = c.Search(context.Background(), SearchOptions{Query: "645", Limit: 5,
+		ListOptions: ListOptions{Fields: []string{"tsn"}, Embed: []string{"manufacturer", "powerSource"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) != 1 || vehicles[0].Manufacturer == nil || vehicles[0].Manufacturer.Name != "BMW" ||
+		vehicles[0].PowerSource == nil || vehicles[0].PowerSource.ID != 1 {
+		t.Fatalf("vehicles are bad, got:'%v'", vehicles)
+	}
+}
+
+func TestClientError(t *testing.T) {
+
+	server := NewTestServer(t)
+	defer server.Close()
+
+	c, err := New(server.URL + "/vehicles")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("get unknown manufacturer")
+	_, err = c.Manufacturer(context.Background(), "000x")
+	if !IsNotFound(err) {
+		t.Fatalf("error is bad, got:'%v' %T", err, err)
+	}
+	if e := err.(*Error); e.Message != "not found" {
+		t.Fatalf("message is bad, got:'%v', want:'%v'", e.Message, "not found")
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+
+	server := NewTestServer(t)
+	defer server.Close()
+
+	c, err := New(server.URL+"/vehicles", WithRetries(1, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("get flaky resource with one retry")
+	err = c.Get(context.Background(), c.resolve("flaky"), &Root{})
+	if !hasStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("error is bad, got:'%v', want status %v", err, http.StatusServiceUnavailable)
+	}
+
+	t.Log("get flaky resource with another retry")
+	if err := c.Get(context.Background(), c.resolve("flaky"), &Root{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+
+	server := NewTestServer(t)
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	c, err := New(server.URL+"/vehicles", WithTimeout(10*time.Millisecond),
+		WithHTTPClient(httpClient), WithRetries(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("get slow resource")
+	if err := c.Get(context.Background(), c.resolve("slow"), &Root{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error is bad, got:'%v', want:'%v'", err, context.DeadlineExceeded)
+	}
+	if httpClient.Timeout != 0 {
+		t.Fatalf("timeout of the http client is bad, got:'%v', want:'%v'", httpClient.Timeout, 0)
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNoLink is returned when a resource has no link with the requested
+// relation.
+var ErrNoLink = errors.New("no link with the relation")
+
+// Error is an error response of the vehicle service.
+type Error struct {
+	StatusCode int    `json:"statusCode"`
+	StatusText string `json:"statusText"`
+	Message    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.StatusText, e.Message)
+}
+
+// IsNotFound returns whether the error is a 404 error response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsBadRequest returns whether the error is a 400 error response.
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+func hasStatus(err error, status int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == status
+}
+
+// retryable returns whether a request resulting in the status should be
+// retried.
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
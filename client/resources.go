@@ -0,0 +1,171 @@
+package client
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Link relations used by the vehicle service.
+const (
+	RelSelf          = "self"
+	RelCanonical     = "canonical"
+	RelManufacturers = "manufacturers"
+	RelManufacturer  = "manufacturer"
+	RelVehicles      = "vehicles"
+	RelPowerSources  = "powerSources"
+	RelPowerSource   = "powerSource"
+)
+
+// Link is a hypermedia link of a resource.
+type Link struct {
+	URL       *url.URL
+	MediaType string
+	Title     string
+	Relation  string
+}
+
+type jsonLink struct {
+	URL       string `json:"href,omitempty"`
+	MediaType string `json:"type,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Relation  string `json:"rel,omitempty"`
+}
+
+// UnmarshalJSON is required by json.Unmarshaler
+func (l *Link) UnmarshalJSON(bytes []byte) error {
+	var s jsonLink
+	if err := json.Unmarshal(bytes, &s); err != nil {
+		return err
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return err
+	}
+	l.URL = u
+	l.MediaType = s.MediaType
+	l.Title = s.Title
+	l.Relation = s.Relation
+	return nil
+}
+
+// MarshalJSON is required by json.Marshaler
+func (l *Link) MarshalJSON() ([]byte, error) {
+	s := &jsonLink{
+		MediaType: l.MediaType,
+		Title:     l.Title,
+		Relation:  l.Relation,
+	}
+	if l.URL != nil {
+		s.URL = l.URL.String()
+	}
+	return json.Marshal(s)
+}
+
+var (
+	_ json.Marshaler   = (*Link)(nil)
+	_ json.Unmarshaler = (*Link)(nil)
+)
+
+// Resource is a resource that can be navigated by its links.
+type Resource interface {
+	Link(relation string) *Link
+}
+
+// Linked is the set of links of a resource.
+type Linked struct {
+	Links []*Link `json:"links,omitempty"`
+}
+
+// Link returns the first link with the relation or nil.
+func (l *Linked) Link(relation string) *Link {
+	for _, link := range l.Links {
+		if link.Relation == relation {
+			return link
+		}
+	}
+	return nil
+}
+
+// Root is the entry point of the vehicle service.
+type Root struct {
+	Linked
+}
+
+// Manufacturer is a vehicle manufacturer.
+type Manufacturer struct {
+	Linked
+	HSN   string              `json:"hsn,omitempty"`
+	Name  string              `json:"name,omitempty"`
+	Names []*ManufacturerName `json:"names,omitempty"`
+}
+
+// ManufacturerName is a name a manufacturer was registered under.
+type ManufacturerName struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Vehicle is a vehicle type.
+type Vehicle struct {
+	Linked
+	HSN              string `json:"hsn,omitempty"`
+	TSN              string `json:"tsn,omitempty"`
+	ManufacturerName string `json:"manufacturerName,omitempty"`
+	TradeName        string `json:"tradeName,omitempty"`
+	CommercialName   string `json:"commercialName,omitempty"`
+	AllotmentDate    string `json:"allotmentDate,omitempty"`
+	Category         string `json:"category,omitempty"`
+	Bodywork         string `json:"bodywork,omitempty"`
+	Power            int    `json:"power,omitempty"`
+	// EngineCapacity, Axles, PoweredAxles, Seats and MaximumMass are nil if
+	// unknown.
+	EngineCapacity *int `json:"engineCapacity,omitempty"`
+	Axles          *int `json:"axles,omitempty"`
+	PoweredAxles   *int `json:"poweredAxles,omitempty"`
+	Seats          *int `json:"seats,omitempty"`
+	MaximumMass    *int `json:"maximumMass,omitempty"`
+	// Manufacturer and PowerSource are only set in listings embedding them.
+	Manufacturer *Manufacturer `json:"manufacturer,omitempty"`
+	PowerSource  *PowerSource  `json:"powerSource,omitempty"`
+}
+
+// PowerSource is the power source of a vehicle.
+type PowerSource struct {
+	Linked
+	ID             int                        `json:"id"`
+	Name           string                     `json:"name,omitempty"`
+	Description    string                     `json:"description,omitempty"`
+	Classification *PowerSourceClassification `json:"classification,omitempty"`
+}
+
+// PowerSourceClassification is the classification of a power source.
+type PowerSourceClassification struct {
+	// FuelFamily is e.g. "petrol", "hybrid", "plug-in-hybrid" or "electric".
+	FuelFamily     string   `json:"fuelFamily"`
+	EnergyCarriers []string `json:"energyCarriers"`
+	Electrified    bool     `json:"electrified"`
+	// HybridType is one of "none", "mild-full" and "plug-in".
+	HybridType string `json:"hybridType"`
+	FuelCell   bool   `json:"fuelCell"`
+	Bivalent   bool   `json:"bivalent"`
+}
+
+// WMI is a world manufacturer identifier.
+type WMI struct {
+	WMI     string `json:"wmi,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// VIN is a decoded vehicle identification number.
+type VIN struct {
+	Linked
+	VIN             string          `json:"vin"`
+	WMI             *WMI            `json:"wmi"`
+	VDS             string          `json:"vds"`
+	VIS             string          `json:"vis"`
+	ModelYears      []int           `json:"modelYears,omitempty"`
+	CheckDigitValid bool            `json:"checkDigitValid"`
+	Manufacturers   []*Manufacturer `json:"manufacturers,omitempty"`
+}
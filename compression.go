@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Content encodings the server can compress responses with.
+const (
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+)
+
+// SupportedEncodings are the content encodings in order of preference.
+var SupportedEncodings = []string{EncodingBrotli, EncodingGzip}
+
+// negotiateEncoding selects the content encoding by the Accept-Encoding
+// header among the encodings, that are in order of preference. It returns an
+// empty string for the identity encoding.
+func negotiateEncoding(r *http.Request, encodings []string) string {
+	weights := map[string]float64{}
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		weights[coding] = weight
+	}
+
+	selected, selectedWeight := "", 0.0
+	for _, encoding := range encodings {
+		weight, ok := weights[encoding]
+		if !ok {
+			weight, ok = weights["*"]
+		}
+		if ok && weight > selectedWeight {
+			selected, selectedWeight = encoding, weight
+		}
+	}
+	return selected
+}
+
+// compress compresses the content with the encoding.
+func compress(encoding string, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case EncodingBrotli:
+		w = brotli.NewWriterLevel(&buf, brotli.DefaultCompression)
+	case EncodingGzip:
+		w = gzip.NewWriter(&buf)
+	default:
+		return content, nil
+	}
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// entityTag returns the strong entity tag of the content. Compressed
+// representations get distinct tags by the suffix of the encoding.
+func entityTag(content []byte, encoding string) string {
+	sum := sha256.Sum256(content)
+	tag := hex.EncodeToString(sum[:12])
+	if encoding != "" {
+		tag += "-" + encoding
+	}
+	return `"` + tag + `"`
+}
+
+// notModified returns whether the If-None-Match header of the request matches
+// the entity tag. The tags are compared weakly.
+func notModified(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
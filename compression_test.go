@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                       "",
+		"gzip":                   EncodingGzip,
+		"gzip, deflate, br":      EncodingBrotli,
+		"br;q=0.5, gzip":         EncodingGzip,
+		"*":                      EncodingBrotli,
+		"br;q=0, *":              EncodingGzip,
+		"identity, deflate":      "",
+		"GZIP;q=0.8, br;q=0.001": EncodingGzip,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Encoding", header)
+		if got := negotiateEncoding(req, SupportedEncodings); got != want {
+			t.Fatalf("encoding of '%s' is bad, got:'%v', want:'%v'", header, got, want)
+		}
+	}
+}
+
+func TestServerCompression(t *testing.T) {
+
+	server := NewServer(WithCompression(100, SupportedEncodings...))
+	server.Get("/large", func(*Context) (interface{}, error) {
+		return map[string]string{"text": strings.Repeat("vehicle ", 100)}, nil
+	})
+	server.Get("/small", func(*Context) (interface{}, error) {
+		return map[string]string{"text": "vehicle"}, nil
+	})
+
+	get := func(path, acceptEncoding, ifNoneMatch string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Log("get uncompressed content")
+	rr := get("/large", "", "")
+	AssertOkStatusCode(t, rr.Code)
+	plain := rr.Body.String()
+	plainETag := rr.Header().Get("ETag")
+	assertHeader(t, rr.Header(), "Content-Encoding", "")
+	if vary := strings.Join(rr.Header().Values("Vary"), ", "); vary != "Accept, Accept-Encoding" {
+		t.Fatalf("vary is bad, got:'%v', want:'%v'", vary, "Accept, Accept-Encoding")
+	}
+
+	for encoding, reader := range map[string]func(io.Reader) (io.Reader, error){
+		EncodingGzip:   func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		EncodingBrotli: func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+	} {
+		t.Logf("get %s compressed content", encoding)
+		rr = get("/large", encoding, "")
+		AssertOkStatusCode(t, rr.Code)
+		assertHeader(t, rr.Header(), "Content-Encoding", encoding)
+		r, err := reader(bytes.NewReader(rr.Body.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != plain {
+			t.Fatalf("content is bad, got:'%v', want:'%v'", string(body), plain)
+		}
+
+		etag := rr.Header().Get("ETag")
+		if etag == plainETag {
+			t.Fatalf("entity tag is bad, got:'%v', want other than:'%v'", etag, plainETag)
+		}
+
+		t.Logf("get %s compressed content not modified", encoding)
+		rr = get("/large", encoding, etag)
+		if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusNotModified)
+		}
+		rr = get("/large", encoding, plainETag)
+		AssertOkStatusCode(t, rr.Code)
+	}
+
+	t.Log("get small content")
+	rr = get("/small", "gzip", "")
+	AssertOkStatusCode(t, rr.Code)
+	assertHeader(t, rr.Header(), "Content-Encoding", "")
+	if vary := strings.Join(rr.Header().Values("Vary"), ", "); vary != "Accept" {
+		t.Fatalf("vary is bad, got:'%v', want:'%v'", vary, "Accept")
+	}
+}
@@ -0,0 +1,530 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the service. It is read from a YAML file,
+// environment variables and command-line flags in increasing precedence.
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	TLS         TLSConfig         `yaml:"tls"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Log         LogConfig         `yaml:"log"`
+	Cache       CacheConfig       `yaml:"cache"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Compression CompressionConfig `yaml:"compression"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+}
+
+// ServerConfig is the configuration of the HTTP and gRPC listeners and of the
+// plain HTTP listener of the health check, that uses neither TLS nor client
+// certificates.
+type ServerConfig struct {
+	Address       string        `yaml:"address"`
+	GRPCAddress   string        `yaml:"grpc_address"`
+	HealthAddress string        `yaml:"health_address"`
+	ReadTimeout   time.Duration `yaml:"read_timeout"`
+	WriteTimeout  time.Duration `yaml:"write_timeout"`
+	IdleTimeout   time.Duration `yaml:"idle_timeout"`
+}
+
+// TLSConfig is the configuration of TLS. TLS is disabled without a
+// certificate. Client certificates are verified against the client CA bundle,
+// if given. The cipher suites do not apply to TLS 1.3.
+type TLSConfig struct {
+	CertFile     string   `yaml:"cert_file"`
+	KeyFile      string   `yaml:"key_file"`
+	MinVersion   string   `yaml:"min_version"`
+	CipherSuites []string `yaml:"cipher_suites"`
+	ClientCAFile string   `yaml:"client_ca_file"`
+	ClientAuth   string   `yaml:"client_auth"`
+}
+
+// DatabaseConfig is the configuration of the store.
+type DatabaseConfig struct {
+	Store        string        `yaml:"store"`
+	DataDir      string        `yaml:"data_dir"`
+	Addr         string        `yaml:"addr"`
+	User         string        `yaml:"user"`
+	Password     string        `yaml:"password"`
+	Name         string        `yaml:"name"`
+	PoolSize     int           `yaml:"pool_size"`
+	MinIdleConns int           `yaml:"min_idle_conns"`
+	DialTimeout  time.Duration `yaml:"dial_timeout"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	PoolTimeout  time.Duration `yaml:"pool_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	Migrate      bool          `yaml:"migrate"`
+}
+
+// LogConfig is the configuration of logging.
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// CacheConfig is the configuration of HTTP caching. Responses are not
+// cacheable with a zero MaxAge.
+type CacheConfig struct {
+	MaxAge time.Duration `yaml:"max_age"`
+}
+
+// CORSConfig is the configuration of cross-origin requests. Origins may be
+// '*' or contain a '*.' subdomain wildcard; headers may be '*'.
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+// CompressionConfig is the configuration of response compression. Responses
+// are not compressed without encodings.
+type CompressionConfig struct {
+	MinSize   int      `yaml:"min_size"`
+	Encodings []string `yaml:"encodings"`
+}
+
+// TracingConfig is the configuration of tracing. Spans are exported to the
+// OTLP/HTTP endpoint, e.g. 'http://localhost:4318', and not recorded without
+// one. The sample ratio applies to traces not started by the caller.
+type TracingConfig struct {
+	Endpoint    string  `yaml:"endpoint"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Log formats.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// DefaultConfig returns the default configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Address:       ":8080",
+			GRPCAddress:   ":9090",
+			HealthAddress: ":8081",
+			ReadTimeout:   10 * time.Second,
+			WriteTimeout:  30 * time.Second,
+			IdleTimeout:   2 * time.Minute,
+		},
+		TLS: TLSConfig{
+			MinVersion: "1.2",
+			ClientAuth: ClientAuthRequire,
+		},
+		Database: DatabaseConfig{
+			Store:        StorePostgres,
+			Addr:         "localhost:5432",
+			User:         "postgres",
+			Password:     "postgres",
+			Name:         "vehicles",
+			PoolSize:     10,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			PoolTimeout:  11 * time.Second,
+			IdleTimeout:  5 * time.Minute,
+		},
+		Log: LogConfig{
+			Level:  logrus.InfoLevel.String(),
+			Format: LogFormatText,
+		},
+		CORS: CORSConfig{
+			AllowedHeaders: []string{"Accept", "Accept-Language", "Content-Type", "X-Request-ID", "traceparent", "tracestate"},
+			ExposedHeaders: []string{"Content-Language", "ETag", "Link", "X-Request-ID"},
+			MaxAge:         10 * time.Minute,
+		},
+		Compression: CompressionConfig{
+			MinSize:   1024,
+			Encodings: []string{EncodingBrotli, EncodingGzip},
+		},
+		Tracing: TracingConfig{
+			ServiceName: "vehicles",
+			SampleRatio: 1,
+		},
+	}
+}
+
+// ConfigErrors are the invalid values of a configuration.
+type ConfigErrors []error
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+// setting binds a configuration value to its flag and environment variable.
+// Settings without a key have no flag.
+type setting struct {
+	key   string
+	env   string
+	value flag.Value
+	usage string
+}
+
+func (c *Config) settings() []*setting {
+	return []*setting{
+		{"", "PORT", (*portValue)(&c.Server.Address), "port of the HTTP server"},
+		{"server.address", "LISTEN_ADDR", (*stringValue)(&c.Server.Address), "address of the HTTP server"},
+		{"", "GRPC_PORT", (*portValue)(&c.Server.GRPCAddress), "port of the gRPC server"},
+		{"server.grpc_address", "GRPC_LISTEN_ADDR", (*stringValue)(&c.Server.GRPCAddress), "address of the gRPC server"},
+		{"server.health_address", "HEALTH_LISTEN_ADDR", (*stringValue)(&c.Server.HealthAddress), "address of the plain HTTP health check"},
+		{"server.read_timeout", "READ_TIMEOUT", (*durationValue)(&c.Server.ReadTimeout), "timeout of reading a request"},
+		{"server.write_timeout", "WRITE_TIMEOUT", (*durationValue)(&c.Server.WriteTimeout), "timeout of writing a response"},
+		{"server.idle_timeout", "IDLE_TIMEOUT", (*durationValue)(&c.Server.IdleTimeout), "timeout of idle keep-alive connections"},
+		{"tls.cert_file", "TLS_CERT_FILE", (*stringValue)(&c.TLS.CertFile), "certificate file, enables TLS"},
+		{"tls.key_file", "TLS_KEY_FILE", (*stringValue)(&c.TLS.KeyFile), "private key file of the certificate"},
+		{"tls.min_version", "TLS_MIN_VERSION", (*stringValue)(&c.TLS.MinVersion), "minimum TLS version: 1.2 or 1.3"},
+		{"tls.cipher_suites", "TLS_CIPHER_SUITES", (*listValue)(&c.TLS.CipherSuites), "comma-separated TLS 1.2 cipher suites, the Go defaults are used if empty"},
+		{"tls.client_ca_file", "TLS_CLIENT_CA_FILE", (*stringValue)(&c.TLS.ClientCAFile), "CA bundle verifying client certificates, enables mutual TLS"},
+		{"tls.client_auth", "TLS_CLIENT_AUTH", (*stringValue)(&c.TLS.ClientAuth), "client certificates: require or verify_if_given"},
+		{"database.store", "STORE", (*stringValue)(&c.Database.Store), "store: postgres or memory"},
+		{"database.data_dir", "DATA_DIR", (*stringValue)(&c.Database.DataDir), "dataset directory of the memory store, the embedded dataset is used if empty"},
+		{"database.addr", "DB_ADDR", (*stringValue)(&c.Database.Addr), "address of the database"},
+		{"database.user", "DB_USER", (*stringValue)(&c.Database.User), "user of the database"},
+		{"database.password", "DB_PASS", (*stringValue)(&c.Database.Password), "password of the database user"},
+		{"database.name", "DB_NAME", (*stringValue)(&c.Database.Name), "name of the database"},
+		{"database.pool_size", "DB_POOL_SIZE", (*intValue)(&c.Database.PoolSize), "maximum number of database connections"},
+		{"database.min_idle_conns", "DB_MIN_IDLE_CONNS", (*intValue)(&c.Database.MinIdleConns), "minimum number of idle database connections"},
+		{"database.dial_timeout", "DB_DIAL_TIMEOUT", (*durationValue)(&c.Database.DialTimeout), "timeout of connecting to the database"},
+		{"database.read_timeout", "DB_READ_TIMEOUT", (*durationValue)(&c.Database.ReadTimeout), "timeout of reading from the database"},
+		{"database.write_timeout", "DB_WRITE_TIMEOUT", (*durationValue)(&c.Database.WriteTimeout), "timeout of writing to the database"},
+		{"database.pool_timeout", "DB_POOL_TIMEOUT", (*durationValue)(&c.Database.PoolTimeout), "timeout of waiting for a database connection"},
+		{"database.idle_timeout", "DB_IDLE_TIMEOUT", (*durationValue)(&c.Database.IdleTimeout), "timeout of idle database connections"},
+		{"database.migrate", "DB_MIGRATE", (*boolValue)(&c.Database.Migrate), "apply pending migrations on start"},
+		{"log.level", "LOG_LEVEL", (*stringValue)(&c.Log.Level), "log level: trace, debug, info, warn or error"},
+		{"log.format", "LOG_FORMAT", (*stringValue)(&c.Log.Format), "log format: text or json"},
+		{"cache.max_age", "CACHE_MAX_AGE", (*durationValue)(&c.Cache.MaxAge), "max-age of cacheable responses"},
+		{"cors.allowed_origins", "CORS_ALLOWED_ORIGINS", (*listValue)(&c.CORS.AllowedOrigins), "comma-separated origins allowed to make cross-origin requests"},
+		{"cors.allowed_headers", "CORS_ALLOWED_HEADERS", (*listValue)(&c.CORS.AllowedHeaders), "comma-separated request headers allowed in cross-origin requests"},
+		{"cors.exposed_headers", "CORS_EXPOSED_HEADERS", (*listValue)(&c.CORS.ExposedHeaders), "comma-separated response headers exposed to cross-origin requests"},
+		{"cors.allow_credentials", "CORS_ALLOW_CREDENTIALS", (*boolValue)(&c.CORS.AllowCredentials), "allow cross-origin requests with credentials"},
+		{"cors.max_age", "CORS_MAX_AGE", (*durationValue)(&c.CORS.MaxAge), "max-age of preflight responses"},
+		{"compression.min_size", "COMPRESSION_MIN_SIZE", (*intValue)(&c.Compression.MinSize), "minimum size in bytes of compressed responses"},
+		{"compression.encodings", "COMPRESSION_ENCODINGS", (*listValue)(&c.Compression.Encodings), "comma-separated encodings in order of preference: br and gzip"},
+		{"tracing.endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT", (*stringValue)(&c.Tracing.Endpoint), "OTLP/HTTP endpoint of the trace collector, enables tracing"},
+		{"tracing.service_name", "OTEL_SERVICE_NAME", (*stringValue)(&c.Tracing.ServiceName), "service name of the traces"},
+		{"tracing.sample_ratio", "TRACING_SAMPLE_RATIO", (*floatValue)(&c.Tracing.SampleRatio), "ratio of sampled traces between 0 and 1"},
+	}
+}
+
+// LoadConfig loads the configuration. It registers the flags on the flag set
+// and parses the arguments. The configuration file is given by the '-config'
+// flag or the CONFIG_FILE environment variable.
+func LoadConfig(fs *flag.FlagSet, args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	config := DefaultConfig()
+	settings := config.settings()
+
+	configFile, _ := lookupEnv("CONFIG_FILE")
+	fs.StringVar(&configFile, "config", configFile, "configuration file (YAML)")
+	flags := map[string]string{}
+	for _, s := range settings {
+		if s.key != "" {
+			key := s.key
+			fs.Func(key, fmt.Sprintf("%s (%s)", s.usage, s.env), func(value string) error {
+				flags[key] = value
+				return nil
+			})
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	if configFile != "" {
+		if err := config.readFile(configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs ConfigErrors
+	for _, s := range settings {
+		if value, ok := lookupEnv(s.env); ok {
+			if err := s.value.Set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %v", s.env, err))
+			}
+		}
+	}
+	for _, s := range settings {
+		if value, ok := flags[s.key]; ok && s.key != "" {
+			if err := s.value.Set(value); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %v", s.key, err))
+			}
+		}
+	}
+	if err := config.Validate(); err != nil {
+		errs = append(errs, err.(ConfigErrors)...)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return config, nil
+}
+
+// readFile reads the YAML configuration file, rejecting unknown keys.
+func (c *Config) readFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("could not read configuration file: %v", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && err != io.EOF {
+		return fmt.Errorf("could not parse configuration file '%s': %v", name, err)
+	}
+	return nil
+}
+
+// Validate validates the configuration and returns all invalid values as
+// ConfigErrors.
+func (c *Config) Validate() error {
+	var errs ConfigErrors
+	check := func(ok bool, format string, a ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, a...))
+		}
+	}
+
+	check(validAddress(c.Server.Address), "server.address is not a valid address: '%s'", c.Server.Address)
+	check(validAddress(c.Server.GRPCAddress), "server.grpc_address is not a valid address: '%s'", c.Server.GRPCAddress)
+	check(validAddress(c.Server.HealthAddress), "server.health_address is not a valid address: '%s'", c.Server.HealthAddress)
+	check(c.Server.Address != c.Server.GRPCAddress, "server.address and server.grpc_address must differ: '%s'", c.Server.Address)
+	check(c.Server.HealthAddress != c.Server.Address && c.Server.HealthAddress != c.Server.GRPCAddress,
+		"server.health_address must differ from server.address and server.grpc_address: '%s'", c.Server.HealthAddress)
+	check(c.Server.ReadTimeout >= 0, "server.read_timeout must not be negative")
+	check(c.Server.WriteTimeout >= 0, "server.write_timeout must not be negative")
+	check(c.Server.IdleTimeout >= 0, "server.idle_timeout must not be negative")
+
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls.cert_file and tls.key_file must be given together")
+	for key, file := range map[string]string{
+		"tls.cert_file":      c.TLS.CertFile,
+		"tls.key_file":       c.TLS.KeyFile,
+		"tls.client_ca_file": c.TLS.ClientCAFile,
+	} {
+		if file != "" {
+			_, err := os.Stat(file)
+			check(err == nil, "%s is not readable: %v", key, err)
+		}
+	}
+	_, ok := tlsVersions[c.TLS.MinVersion]
+	check(ok, "tls.min_version must be '1.2' or '1.3': '%s'", c.TLS.MinVersion)
+	for _, name := range c.TLS.CipherSuites {
+		_, ok := tlsCipherSuite(name)
+		check(ok, "tls.cipher_suites contains an unknown or insecure cipher suite: '%s'", name)
+	}
+	check(c.TLS.ClientCAFile == "" || c.TLS.CertFile != "", "tls.client_ca_file requires tls.cert_file")
+	check(c.TLS.ClientAuth == ClientAuthRequire || c.TLS.ClientAuth == ClientAuthVerifyIfGiven,
+		"tls.client_auth must be '%s' or '%s': '%s'", ClientAuthRequire, ClientAuthVerifyIfGiven, c.TLS.ClientAuth)
+
+	switch c.Database.Store {
+	case StorePostgres:
+		check(validAddress(c.Database.Addr), "database.addr is not a valid address: '%s'", c.Database.Addr)
+		check(c.Database.Name != "", "database.name must not be empty")
+		check(c.Database.PoolSize > 0, "database.pool_size must be positive")
+		check(c.Database.MinIdleConns >= 0 && c.Database.MinIdleConns <= c.Database.PoolSize,
+			"database.min_idle_conns must be between 0 and database.pool_size")
+		for key, timeout := range map[string]time.Duration{
+			"database.dial_timeout":  c.Database.DialTimeout,
+			"database.read_timeout":  c.Database.ReadTimeout,
+			"database.write_timeout": c.Database.WriteTimeout,
+			"database.pool_timeout":  c.Database.PoolTimeout,
+			"database.idle_timeout":  c.Database.IdleTimeout,
+		} {
+			check(timeout >= 0, "%s must not be negative", key)
+		}
+	case StoreMemory:
+		if c.Database.DataDir != "" {
+			info, err := os.Stat(c.Database.DataDir)
+			check(err == nil && info.IsDir(), "database.data_dir is not a directory: '%s'", c.Database.DataDir)
+		}
+		check(!c.Database.Migrate, "database.migrate requires the postgres store")
+	default:
+		check(false, "database.store must be '%s' or '%s': '%s'", StorePostgres, StoreMemory, c.Database.Store)
+	}
+
+	_, err := logrus.ParseLevel(c.Log.Level)
+	check(err == nil, "log.level is not a valid level: '%s'", c.Log.Level)
+	check(c.Log.Format == LogFormatText || c.Log.Format == LogFormatJSON,
+		"log.format must be '%s' or '%s': '%s'", LogFormatText, LogFormatJSON, c.Log.Format)
+
+	check(c.Cache.MaxAge >= 0, "cache.max_age must not be negative")
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		check(validOrigin(origin), "cors.allowed_origins contains an invalid origin: '%s'", origin)
+	}
+	check(c.CORS.MaxAge >= 0, "cors.max_age must not be negative")
+
+	check(c.Compression.MinSize >= 0, "compression.min_size must not be negative")
+	for _, encoding := range c.Compression.Encodings {
+		check(containsString(SupportedEncodings, encoding), "compression.encodings contains an unknown encoding: '%s'", encoding)
+	}
+
+	if c.Tracing.Endpoint != "" {
+		u, err := url.Parse(c.Tracing.Endpoint)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"tracing.endpoint must be a http or https URL: '%s'", c.Tracing.Endpoint)
+	}
+	check(c.Tracing.ServiceName != "", "tracing.service_name must not be empty")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// validAddress returns whether the address is a 'host:port' address.
+func validAddress(address string) bool {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 0 && n <= 65535
+}
+
+// validOrigin returns whether the origin is '*' or a scheme and a host, that
+// may start with a '*.' wildcard.
+func validOrigin(origin string) bool {
+	if origin == "*" {
+		return true
+	}
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	return err == nil && u.Scheme != "" && u.Host != "" && (u.Path == "" || u.Path == "/") &&
+		u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
+
+// String returns the configuration as YAML with the password redacted.
+func (c *Config) String() string {
+	redacted := *c
+	if redacted.Database.Password != "" {
+		redacted.Database.Password = "********"
+	}
+	bytes, _ := yaml.Marshal(&redacted)
+	return string(bytes)
+}
+
+// NewLogger creates a new logger configured by the LogConfig.
+func (c *LogConfig) NewLogger() *logrus.Logger {
+	logger := logrus.New()
+	if level, err := logrus.ParseLevel(c.Level); err == nil {
+		logger.SetLevel(level)
+	}
+	if c.Format == LogFormatJSON {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+	return logger
+}
+
+type stringValue string
+
+func (v *stringValue) String() string { return string(*v) }
+
+func (v *stringValue) Set(s string) error {
+	*v = stringValue(s)
+	return nil
+}
+
+type intValue int
+
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+func (v *intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("not an integer: '%s'", s)
+	}
+	*v = intValue(n)
+	return nil
+}
+
+type floatValue float64
+
+func (v *floatValue) String() string { return strconv.FormatFloat(float64(*v), 'g', -1, 64) }
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("not a number: '%s'", s)
+	}
+	*v = floatValue(f)
+	return nil
+}
+
+type boolValue bool
+
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("not a boolean: '%s'", s)
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("not a duration: '%s'", s)
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+type listValue []string
+
+func (v *listValue) String() string { return strings.Join(*v, ",") }
+
+func (v *listValue) Set(s string) error {
+	*v = nil
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*v = append(*v, item)
+		}
+	}
+	return nil
+}
+
+// portValue sets the address to listen on all interfaces at the port.
+type portValue string
+
+func (v *portValue) String() string { return string(*v) }
+
+func (v *portValue) Set(s string) error {
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("not a port: '%s'", s)
+	}
+	*v = portValue(fmt.Sprintf(":%d", port))
+	return nil
+}
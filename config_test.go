@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadTestConfig(t *testing.T, env map[string]string, args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return LoadConfig(fs, args, func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	})
+}
+
+func writeTestConfigFile(t *testing.T, content string) string {
+	name := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(name, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+
+	t.Log("load default config")
+	config, err := loadTestConfig(t, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Server.Address != ":8080" || config.Database.Store != StorePostgres {
+		t.Fatalf("config is bad, got:'%v'", config)
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+
+	file := writeTestConfigFile(t, `
+server:
+  address: ":8000"
+  read_timeout: 3s
+database:
+  user: file
+  pool_size: 20
+log:
+  level: debug
+`)
+
+	t.Log("load config from file, env and flags")
+	env := map[string]string{
+		"CONFIG_FILE":  file,
+		"DB_USER":      "env",
+		"DB_POOL_SIZE": "30",
+		"GRPC_PORT":    "9191",
+	}
+	config, err := loadTestConfig(t, env, "-database.pool_size", "40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Server.Address != ":8000" || config.Server.ReadTimeout != 3*time.Second {
+		t.Fatalf("file values are bad, got:'%v'", config.Server)
+	}
+	if config.Database.User != "env" || config.Server.GRPCAddress != ":9191" {
+		t.Fatalf("env values are bad, got:'%v', '%v'", config.Database.User, config.Server.GRPCAddress)
+	}
+	if config.Database.PoolSize != 40 {
+		t.Fatalf("pool size is bad, got:'%v', want:'%v'", config.Database.PoolSize, 40)
+	}
+	if config.Log.Level != "debug" || config.Database.Name != "vehicles" {
+		t.Fatalf("remaining values are bad, got:'%v', '%v'", config.Log.Level, config.Database.Name)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+
+	t.Log("load config with invalid values")
+	env := map[string]string{"PORT": "80a", "STORE": "mongo", "LOG_FORMAT": "xml"}
+	_, err := loadTestConfig(t, env, "-cache.max_age", "-1s", "-cors.allowed_origins", "https://*.envirocar.org,envirocar.org")
+
+	var errs ConfigErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error is bad, got:'%v' %T", err, err)
+	}
+	for _, want := range []string{"PORT", "database.store", "log.format", "cache.max_age", "'envirocar.org'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error is bad, got:'%v', want:'%v'", err, want)
+		}
+	}
+	if len(errs) != 5 {
+		t.Fatalf("number of errors is bad, got:'%v', want:'%v'", len(errs), 5)
+	}
+}
+
+func TestLoadConfigUnknownKey(t *testing.T) {
+
+	t.Log("load config file with unknown key")
+	file := writeTestConfigFile(t, "database:\n  host: localhost\n")
+	if _, err := loadTestConfig(t, nil, "-config", file); err == nil || !strings.Contains(err.Error(), "host") {
+		t.Fatalf("error is bad, got:'%v'", err)
+	}
+}
+
+func TestConfigString(t *testing.T) {
+
+	t.Log("print config")
+	config := DefaultConfig()
+	config.Database.Password = "secret"
+	if s := config.String(); strings.Contains(s, "secret") || !strings.Contains(s, "read_timeout: 10s") {
+		t.Fatalf("config is bad, got:'%v'", s)
+	}
+	if config.Database.Password != "secret" {
+		t.Fatalf("password is bad, got:'%v', want:'%v'", config.Database.Password, "secret")
+	}
+}
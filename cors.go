@@ -0,0 +1,151 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// handleCORS adds the CORS headers of allowed origins to the response and
+// answers preflight requests of registered routes. It returns whether the
+// request was answered.
+func (s *Server) handleCORS(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+	var methods []string
+	if preflight {
+		if methods = s.routeMethods(r); len(methods) == 0 {
+			// unknown path, answered by the router
+			return false
+		}
+		w.Header().Add("Vary", "Origin")
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	allowed := s.allowedOrigin(origin)
+	if allowed != "*" {
+		if !preflight {
+			w.Header().Add("Vary", "Origin")
+		}
+		if allowed == "" {
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return preflight
+		}
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", allowed)
+	if s.cors.AllowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	if !preflight {
+		if len(s.cors.ExposedHeaders) > 0 {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(s.cors.ExposedHeaders, ", "))
+		}
+		return false
+	}
+
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+	if headers := s.allowedHeaders(r); headers != "" {
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+	}
+	if s.cors.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(s.cors.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// allowedOrigin returns the Access-Control-Allow-Origin value for the origin
+// or an empty string if it is not allowed. A wildcard is answered with the
+// origin itself if credentials are allowed.
+func (s *Server) allowedOrigin(origin string) string {
+	for _, allowed := range s.cors.AllowedOrigins {
+		if allowed == "*" {
+			if s.cors.AllowCredentials {
+				return origin
+			}
+			return "*"
+		}
+		if allowed == origin {
+			return origin
+		}
+		if matchesWildcardOrigin(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// matchesWildcardOrigin returns whether the origin matches the allowed origin
+// with a '*.' wildcard, i.e. has its scheme and a host of one or more
+// non-empty labels followed by the rest of the allowed host and port.
+func matchesWildcardOrigin(allowed, origin string) bool {
+	i := strings.Index(allowed, "://*.")
+	if i < 0 {
+		return false
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme != allowed[:i] || u.User != nil || u.Path != "" ||
+		u.RawQuery != "" || u.Fragment != "" {
+		return false
+	}
+	labels := strings.TrimSuffix(u.Host, allowed[i+5:])
+	if labels == u.Host || !strings.HasSuffix(labels, ".") {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(labels, "."), ".") {
+		if label == "" || strings.ContainsAny(label, ":[]") {
+			return false
+		}
+	}
+	return true
+}
+
+// allowedHeaders returns the Access-Control-Allow-Headers value of a
+// preflight request. A wildcard allows the requested headers.
+func (s *Server) allowedHeaders(r *http.Request) string {
+	for _, header := range s.cors.AllowedHeaders {
+		if header == "*" {
+			return r.Header.Get("Access-Control-Request-Headers")
+		}
+	}
+	return strings.Join(s.cors.AllowedHeaders, ", ")
+}
+
+// routeMethods returns the methods of the routes matching the path of the
+// request.
+func (s *Server) routeMethods(r *http.Request) []string {
+	var methods []string
+	s.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		var match mux.RouteMatch
+		if route.Match(r, &match) || match.MatchErr == mux.ErrMethodMismatch {
+			routeMethods, _ := route.GetMethods()
+			for _, method := range routeMethods {
+				if !containsString(methods, method) {
+					methods = append(methods, method)
+				}
+			}
+		}
+		return nil
+	})
+	return methods
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func buildTestCORSServer(config CORSConfig) *Server {
+	server := NewServer(WithCORS(config))
+	server.Get("/ping", func(*Context) (interface{}, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	server.Post("/ping", func(*Context) (interface{}, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+	return server
+}
+
+func newTestPreflightRequest(t *testing.T, path, origin string) *http.Request {
+	req, err := http.NewRequest(http.MethodOptions, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "processing.envirocar.org"
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type")
+	return req
+}
+
+func assertHeader(t *testing.T, header http.Header, name, want string) {
+	if got := header.Get(name); got != want {
+		t.Fatalf("header %s is bad, got:'%v', want:'%v'", name, got, want)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+
+	config := DefaultConfig().CORS
+	config.AllowedOrigins = []string{"https://*.envirocar.org"}
+	config.AllowCredentials = true
+	server := buildTestCORSServer(config)
+
+	t.Log("send preflight request")
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, newTestPreflightRequest(t, "/ping", "https://dashboard.envirocar.org"))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusNoContent)
+	}
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Origin", "https://dashboard.envirocar.org")
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Methods", "GET, HEAD, POST")
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Headers", "Accept, Accept-Language, Content-Type, X-Request-ID, traceparent, tracestate")
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Credentials", "true")
+	assertHeader(t, rr.Header(), "Access-Control-Max-Age", "600")
+
+	t.Log("send preflight request from other origin")
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, newTestPreflightRequest(t, "/ping", "https://example.org"))
+
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusNoContent)
+	}
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Origin", "")
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Methods", "")
+
+	for _, origin := range []string{
+		"https://.envirocar.org",
+		"https://a..envirocar.org",
+		"https://envirocar.org",
+		"http://dashboard.envirocar.org",
+		"https://dashboard.envirocar.org.example.org",
+		"https://user@dashboard.envirocar.org",
+	} {
+		t.Logf("send preflight request from '%s'", origin)
+		rr = httptest.NewRecorder()
+		server.ServeHTTP(rr, newTestPreflightRequest(t, "/ping", origin))
+		assertHeader(t, rr.Header(), "Access-Control-Allow-Origin", "")
+	}
+
+	t.Log("send preflight request from nested subdomain")
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, newTestPreflightRequest(t, "/ping", "https://a.b.envirocar.org"))
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Origin", "https://a.b.envirocar.org")
+
+	t.Log("send preflight request to unknown path")
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, newTestPreflightRequest(t, "/pong", "https://dashboard.envirocar.org"))
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusNotFound)
+	}
+}
+
+func TestCORSRequest(t *testing.T) {
+
+	server := buildTestCORSServer(CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		ExposedHeaders: []string{"ETag", "Link", "X-Request-ID"},
+		MaxAge:         time.Minute,
+	})
+
+	t.Log("send preflight request with wildcard headers")
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, newTestPreflightRequest(t, "/ping", "https://example.org"))
+
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Origin", "*")
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Headers", "content-type")
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Credentials", "")
+
+	req, err := http.NewRequest(http.MethodGet, "/ping", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "processing.envirocar.org"
+	req.Header.Set("Origin", "https://example.org")
+	req.Header.Set("X-Request-ID", "42")
+
+	t.Log("send cross-origin request")
+	rr = httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+
+	AssertOkStatusCode(t, rr.Code)
+	assertHeader(t, rr.Header(), "Access-Control-Allow-Origin", "*")
+	assertHeader(t, rr.Header(), "Access-Control-Expose-Headers", "ETag, Link, X-Request-ID")
+	assertHeader(t, rr.Header(), "X-Request-ID", "42")
+}
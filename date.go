@@ -0,0 +1,139 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Date layouts.
+const (
+	// DateLayout is the ISO 8601 calendar date layout dates are formatted in.
+	DateLayout = "2006-01-02"
+	// germanDateLayout is the layout of the KBA dataset and of the German
+	// DateStyle of Postgres.
+	germanDateLayout = "02.01.2006"
+)
+
+// Date is a calendar date. It is formatted as ISO 8601, independent of the
+// DateStyle of the database session. The zero Date is unknown and encoded as
+// JSON null.
+type Date struct{ t time.Time }
+
+var _ json.Marshaler = Date{}
+
+// NewDate creates a new Date.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the date of the time in its location.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// ParseDate parses an ISO 8601 calendar date, e.g. '2003-07-01'.
+func ParseDate(value string) (Date, error) {
+	return parseDate(DateLayout, value)
+}
+
+func parseDate(layout, value string) (Date, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("date is bad '%s', want:'%s'", value, layout)
+	}
+	return DateOf(t), nil
+}
+
+// Time returns the start of the date in UTC.
+func (d Date) Time() time.Time {
+	return d.t
+}
+
+// IsZero reports whether the date is unknown.
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Before reports whether the date is before the other.
+func (d Date) Before(other Date) bool {
+	return d.t.Before(other.t)
+}
+
+// After reports whether the date is after the other.
+func (d Date) After(other Date) bool {
+	return d.t.After(other.t)
+}
+
+// String returns the date formatted as ISO 8601 or an empty string if it is
+// unknown.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(DateLayout)
+}
+
+// MarshalJSON is required by json.Marshaler
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON is required by json.Unmarshaler
+func (d *Date) UnmarshalJSON(bytes []byte) error {
+	var value *string
+	if err := json.Unmarshal(bytes, &value); err != nil {
+		return err
+	}
+	if value == nil || *value == "" {
+		*d = Date{}
+		return nil
+	}
+	date, err := ParseDate(*value)
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// Scan is required by sql.Scanner. Besides dates it accepts the ISO 8601 and
+// the German DateStyle.
+func (d *Date) Scan(src interface{}) error {
+	var value string
+	switch src := src.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		*d = DateOf(src)
+		return nil
+	case []byte:
+		value = string(src)
+	case string:
+		value = src
+	default:
+		return fmt.Errorf("cannot scan %T into a date", src)
+	}
+	date, err := ParseDate(value)
+	if err != nil {
+		date, err = parseDate(germanDateLayout, value)
+	}
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// Value is required by driver.Valuer
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
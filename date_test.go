@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateJSON(t *testing.T) {
+
+	t.Log("marshal dates")
+	bytes, err := json.Marshal(&DateRange{From: NewDate(2003, time.July, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(bytes), `{"from":"2003-07-01","to":null}`; got != want {
+		t.Fatalf("json is bad, got:'%v', want:'%v'", got, want)
+	}
+
+	t.Log("unmarshal dates")
+	var r DateRange
+	if err := json.Unmarshal([]byte(`{"from":"2003-07-01","to":null}`), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.From != NewDate(2003, time.July, 1) || !r.To.IsZero() {
+		t.Fatalf("dates are bad, got:'%v'", r)
+	}
+
+	t.Log("unmarshal bad date")
+	if err := json.Unmarshal([]byte(`{"from":"01.07.2003"}`), &r); err == nil {
+		t.Fatal("error is nil")
+	}
+}
+
+func TestDateScan(t *testing.T) {
+
+	want := NewDate(2003, time.July, 1)
+	for _, src := range []interface{}{
+		[]byte("2003-07-01"),
+		"01.07.2003",
+		time.Date(2003, time.July, 1, 0, 0, 0, 0, time.FixedZone("CEST", 7200)),
+	} {
+		t.Logf("scan '%v'", src)
+		var d Date
+		if err := d.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if d != want {
+			t.Fatalf("date is bad, got:'%v', want:'%v'", d, want)
+		}
+	}
+
+	t.Log("scan null")
+	d := want
+	if err := d.Scan(nil); err != nil || !d.IsZero() {
+		t.Fatalf("date is bad, got:'%v', '%v'", d, err)
+	}
+
+	t.Log("scan bad date")
+	if err := d.Scan("07/01/2003"); err == nil {
+		t.Fatal("error is nil")
+	}
+}
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS vehicles (
+  manufacturer_id char(4) NOT NULL,
+  id char(3) NOT NULL,
+  manufacturer_name text,
+  trade_name text,
+  commercial_name text,
+  allotment_date date NOT NULL,
+  category varchar(3) NOT NULL,
+  bodywork varchar(4),
+  power_source_id int NOT NULL,
+  power int NOT NULL,
+  engine_capacity int,
+  axles int,
+  powered_axles int,
+  seats int,
+  maximum_mass int,
+  CONSTRAINT vehicles_pkey PRIMARY KEY (manufacturer_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS manufacturers (
+  id char(4) PRIMARY KEY,
+  name text
+);
+
+CREATE TABLE IF NOT EXISTS manufacturer_names (
+  manufacturer_id char(4) NOT NULL,
+  name text NOT NULL,
+  valid_from date NOT NULL,
+  valid_to date NOT NULL,
+  CONSTRAINT manufacturer_names_pkey PRIMARY KEY (manufacturer_id, name, valid_from)
+);
+
+CREATE TABLE IF NOT EXISTS power_sources (
+  id int PRIMARY KEY,
+  short_name text,
+  description text
+);
+
+CREATE TABLE IF NOT EXISTS power_source_translations (
+  power_source_id int NOT NULL,
+  language varchar(8) NOT NULL,
+  short_name text,
+  description text,
+  CONSTRAINT power_source_translations_pkey PRIMARY KEY (power_source_id, language)
+);
+
+CREATE TABLE IF NOT EXISTS wmis (
+  id char(3) PRIMARY KEY,
+  name text NOT NULL,
+  country char(2)
+);
+
+CREATE TABLE IF NOT EXISTS wmi_manufacturers (
+  wmi_id char(3) NOT NULL,
+  manufacturer_id char(4) NOT NULL,
+  CONSTRAINT wmi_manufacturers_pkey PRIMARY KEY (wmi_id, manufacturer_id)
+);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS vehicles_power_source_id_idx ON vehicles (power_source_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS power_source_classifications (
+  power_source_id int PRIMARY KEY REFERENCES power_sources(id),
+  fuel_family text NOT NULL,
+  energy_carriers text[] NOT NULL,
+  electrified boolean NOT NULL,
+  hybrid_type text NOT NULL,
+  fuel_cell boolean NOT NULL,
+  bivalent boolean NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS power_source_classifications_fuel_family_idx ON power_source_classifications (fuel_family);
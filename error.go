@@ -9,13 +9,20 @@ import (
 var (
 	// ErrInternalServer is a 500 error
 	ErrInternalServer = NewError(http.StatusInternalServerError, errors.New("internal server error"))
+	// ErrBadRequest is a 400 error.
+	ErrBadRequest = NewError(http.StatusBadRequest, errors.New("bad request"))
 	// ErrNotFound is a 404 error.
 	ErrNotFound = NewError(http.StatusNotFound, errors.New("not found"))
 	// ErrMethodNotAllowed is a 405 error.
 	ErrMethodNotAllowed = NewError(http.StatusMethodNotAllowed, errors.New("method not allowed"))
 )
 
-// NewErrBadRequestF returns a 404 bad request error
+// NewErrBadRequestF returns a 400 bad request error
+func NewErrBadRequestF(format string, a ...interface{}) Error {
+	return NewError(http.StatusBadRequest, fmt.Errorf(format, a...))
+}
+
+// NewErrNotFoundF returns a 404 not found error
 func NewErrNotFoundF(format string, a ...interface{}) Error {
 	return NewError(http.StatusNotFound, fmt.Errorf(format, a...))
 }
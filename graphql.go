@@ -0,0 +1,764 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+const (
+	// graphQLMaxDepth is the maximum nesting of fields in a query.
+	graphQLMaxDepth = 8
+	// graphQLMaxComplexity is the maximum estimated number of resolved fields
+	// of a query.
+	graphQLMaxComplexity = 5000
+	// graphQLMaxListSize is the maximum value of a 'first' argument and the
+	// assumed size of unbounded lists when estimating the complexity.
+	graphQLMaxListSize = 1000
+	// graphQLMaxRequestSize is the maximum size of a request body.
+	graphQLMaxRequestSize = 1 << 20
+)
+
+// graphQLListSizes are the assumed sizes of the lists without a 'first'
+// argument, that are bounded by the dataset, by their type and field name.
+// The largest lists of the dataset hold 20 manufacturer names and 38 power
+// sources.
+var graphQLListSizes = map[string]int{
+	"Manufacturer.names": 25,
+	"Query.powerSources": 100,
+}
+
+// GraphQL is the GraphQL endpoint over the vehicle repository.
+type GraphQL struct {
+	repository Repository
+	schema     graphql.Schema
+}
+
+// NewGraphQL creates a new GraphQL endpoint.
+func NewGraphQL(repository Repository) (*GraphQL, error) {
+	g := &GraphQL{repository: repository}
+	schema, err := g.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	g.schema = schema
+	return g, nil
+}
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query executes a GraphQL query given either as query parameters or as the
+// request body.
+func (g *GraphQL) Query(context *Context) (interface{}, error) {
+
+	context.logger.Info("graphql query")
+
+	request, err := g.parseRequest(context.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	src := source.NewSource(&source.Source{
+		Body: []byte(request.Query),
+		Name: "GraphQL request",
+	})
+	document, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, nil
+	}
+
+	validation := graphql.ValidateDocument(&g.schema, document, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}, nil
+	}
+
+	if err := g.checkLimits(document, request.OperationName, request.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}, nil
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema: g.schema,
+		Root: map[string]interface{}{
+			"context": context,
+			"loader":  newGraphQLLoader(g.repository.WithContext(context.Request.Context())),
+		},
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+	}), nil
+}
+
+func (g *GraphQL) parseRequest(r *http.Request) (*graphQLRequest, error) {
+	request := &graphQLRequest{}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		query := r.URL.Query()
+		request.Query = query.Get("query")
+		request.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				return nil, NewErrBadRequestF("variables are not a JSON object: %v", err)
+			}
+		}
+	} else {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, graphQLMaxRequestSize))
+		if err != nil {
+			return nil, NewErrBadRequestF("could not read request: %v", err)
+		}
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/graphql") {
+			request.Query = string(body)
+		} else if err := json.Unmarshal(body, request); err != nil {
+			return nil, NewErrBadRequestF("request is not a GraphQL JSON request: %v", err)
+		}
+	}
+	if request.Query == "" {
+		return nil, NewErrBadRequestF("query is missing")
+	}
+	return request, nil
+}
+
+// checkLimits rejects operations exceeding the maximum depth or complexity.
+func (g *GraphQL) checkLimits(document *ast.Document, operationName string, variables map[string]interface{}) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operations = append(operations, d)
+			}
+		}
+	}
+	for _, operation := range operations {
+		c := &complexityCalculator{fragments: fragments, variables: variables}
+		root := g.schema.QueryType()
+		complexity := c.selectionSet(root, operation.SelectionSet, 1)
+		if c.depth > graphQLMaxDepth {
+			return fmt.Errorf("query depth %d exceeds the maximum depth of %d", c.depth, graphQLMaxDepth)
+		}
+		if complexity > graphQLMaxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the maximum complexity of %d", complexity, graphQLMaxComplexity)
+		}
+	}
+	return nil
+}
+
+// complexityCalculator estimates the number of fields resolved by a query.
+// Every field costs one, the fields selected on lists are multiplied by the
+// 'first' argument or its default, the size of the list in graphQLListSizes
+// or graphQLMaxListSize.
+type complexityCalculator struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	depth     int
+}
+
+func (c *complexityCalculator) selectionSet(parent *graphql.Object, set *ast.SelectionSet, depth int) int {
+	if set == nil {
+		return 0
+	}
+	if depth > c.depth {
+		c.depth = depth
+	}
+	complexity := 0
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			complexity += c.field(parent, s, depth)
+		case *ast.InlineFragment:
+			complexity += c.selectionSet(parent, s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := c.fragments[s.Name.Value]; ok {
+				complexity += c.selectionSet(parent, fragment.SelectionSet, depth)
+			}
+		}
+	}
+	return complexity
+}
+
+func (c *complexityCalculator) field(parent *graphql.Object, field *ast.Field, depth int) int {
+	if field.SelectionSet == nil {
+		return 1
+	}
+	var fieldType graphql.Type
+	var definition *graphql.FieldDefinition
+	if parent != nil {
+		if d, ok := parent.Fields()[field.Name.Value]; ok {
+			definition, fieldType = d, d.Type
+		}
+	}
+	multiplier := 1
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	if list, ok := fieldType.(*graphql.List); ok {
+		fieldType = list.OfType
+		multiplier = graphQLListSize(parent, definition)
+		for _, argument := range field.Arguments {
+			if argument.Name.Value != "first" {
+				continue
+			}
+			switch value := argument.Value.(type) {
+			case *ast.IntValue:
+				if first, err := strconv.Atoi(value.Value); err == nil {
+					multiplier = first
+				}
+			case *ast.Variable:
+				if first, ok := c.variables[value.Name.Value].(float64); ok {
+					multiplier = int(first)
+				}
+			}
+		}
+		// 'first' is at least one, lesser values are rejected by the resolver
+		if multiplier < 1 {
+			multiplier = 1
+		}
+	}
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	child, _ := fieldType.(*graphql.Object)
+	return 1 + multiplier*c.selectionSet(child, field.SelectionSet, depth+1)
+}
+
+// graphQLListSize returns the assumed size of the list field: the default of
+// its 'first' argument, its size in graphQLListSizes or graphQLMaxListSize.
+func graphQLListSize(parent *graphql.Object, definition *graphql.FieldDefinition) int {
+	if definition == nil {
+		return graphQLMaxListSize
+	}
+	for _, argument := range definition.Args {
+		if argument.Name() == "first" {
+			if first, ok := argument.DefaultValue.(int); ok {
+				return first
+			}
+			return graphQLMaxListSize
+		}
+	}
+	if size, ok := graphQLListSizes[parent.Name()+"."+definition.Name]; ok {
+		return size
+	}
+	return graphQLMaxListSize
+}
+
+func graphQLContext(p graphql.ResolveParams) *Context {
+	return p.Info.RootValue.(map[string]interface{})["context"].(*Context)
+}
+
+// repositoryOf returns the repository bound to the request of the resolution.
+func (g *GraphQL) repositoryOf(p graphql.ResolveParams) Repository {
+	return g.repository.WithContext(graphQLContext(p).Request.Context())
+}
+
+// graphQLLoader loads the manufacturers and power sources of the vehicles of a
+// request in batches. Its resolvers return thunks, that are called after all
+// vehicles of a list are resolved, so that the manufacturers of a list are
+// loaded by a single query and the power sources once per request.
+type graphQLLoader struct {
+	repository    Repository
+	pending       []string
+	manufacturers map[string]*Manufacturer
+	powerSources  map[int]*PowerSource
+	err           error
+}
+
+func newGraphQLLoader(repository Repository) *graphQLLoader {
+	return &graphQLLoader{repository: repository, manufacturers: map[string]*Manufacturer{}}
+}
+
+func graphQLLoaderOf(p graphql.ResolveParams) *graphQLLoader {
+	return p.Info.RootValue.(map[string]interface{})["loader"].(*graphQLLoader)
+}
+
+// manufacturer returns a thunk of the manufacturer with the id, that loads it
+// along with all other pending manufacturers.
+func (l *graphQLLoader) manufacturer(p graphql.ResolveParams, id string) func() (interface{}, error) {
+	if _, ok := l.manufacturers[id]; !ok && !containsString(l.pending, id) {
+		l.pending = append(l.pending, id)
+	}
+	return func() (interface{}, error) {
+		if len(l.pending) > 0 {
+			manufacturers, err := l.repository.GetManufacturersByIDs(l.pending)
+			for _, id := range l.pending {
+				l.manufacturers[id] = nil
+			}
+			l.pending = nil
+			if err != nil {
+				l.err = err
+			}
+			for _, m := range manufacturers {
+				l.manufacturers[m.ID] = m
+			}
+		}
+		if m := l.manufacturers[id]; m != nil {
+			return m, nil
+		}
+		if l.err != nil {
+			return graphQLResult(p, nil, l.err)
+		}
+		return nil, nil
+	}
+}
+
+// powerSource returns the power source with the id in the language, loading
+// all power sources on the first call.
+func (l *graphQLLoader) powerSource(p graphql.ResolveParams, id int, language string) (interface{}, error) {
+	if l.powerSources == nil {
+		entities, err := l.repository.GetPowerSources(language)
+		if err != nil {
+			return graphQLResult(p, nil, err)
+		}
+		l.powerSources = make(map[int]*PowerSource, len(entities))
+		for _, ps := range entities {
+			l.powerSources[ps.ID] = ps
+		}
+	}
+	if ps, ok := l.powerSources[id]; ok {
+		return ps, nil
+	}
+	return nil, nil
+}
+
+// graphQLResult maps repository results to field results: missing entities
+// resolve to null, other errors are logged and hidden from the client.
+func graphQLResult(p graphql.ResolveParams, value interface{}, err error) (interface{}, error) {
+	if err == nil {
+		return value, nil
+	}
+	if e, ok := err.(Error); ok && e.Status() == http.StatusNotFound {
+		return nil, nil
+	}
+	graphQLContext(p).logger.WithError(err).Errorf("could not resolve field '%s'", p.Info.FieldName)
+	return nil, ErrInternalServer
+}
+
+// graphQLDate is the scalar of dates.
+var graphQLDate = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Date",
+	Description: "An ISO 8601 calendar date, e.g. 2003-07-01.",
+	Serialize: func(value interface{}) interface{} {
+		if date, ok := value.(Date); ok && !date.IsZero() {
+			return date.String()
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			if date, err := ParseDate(s); err == nil {
+				return date
+			}
+		}
+		return nil
+	},
+	ParseLiteral: func(value ast.Value) interface{} {
+		if s, ok := value.(*ast.StringValue); ok {
+			if date, err := ParseDate(s.Value); err == nil {
+				return date
+			}
+		}
+		return nil
+	},
+})
+
+var graphQLVehicleFilterArgs = graphql.FieldConfigArgument{
+	"tradeName": &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "Part of the trade name, case insensitive.",
+	},
+	"commercialName": &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "Part of the commercial name, case insensitive.",
+	},
+	"category": &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: "The vehicle category code.",
+	},
+	"fuelFamily": &graphql.ArgumentConfig{
+		Type:        graphql.String,
+		Description: fmt.Sprintf("The fuel family of the power source, one of %s.", strings.Join(FuelFamilies, ", ")),
+	},
+	"allotmentDateFrom": &graphql.ArgumentConfig{
+		Type:        graphQLDate,
+		Description: "The earliest allotment date.",
+	},
+	"allotmentDateTo": &graphql.ArgumentConfig{
+		Type:        graphQLDate,
+		Description: "The latest allotment date.",
+	},
+	"minPower": &graphql.ArgumentConfig{
+		Type:        graphql.Int,
+		Description: "The minimum power in kW.",
+	},
+	"maxPower": &graphql.ArgumentConfig{
+		Type:        graphql.Int,
+		Description: "The maximum power in kW.",
+	},
+	"first": &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: 100,
+		Description:  fmt.Sprintf("The maximum number of vehicles, from 1 to %d.", graphQLMaxListSize),
+	},
+	"offset": &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: 0,
+		Description:  "The number of vehicles to skip.",
+	},
+}
+
+// vehicleFilter creates the VehicleFilter from the arguments of a vehicles field.
+func vehicleFilter(args map[string]interface{}) (*VehicleFilter, error) {
+	filter := &VehicleFilter{}
+	filter.ManufacturerID, _ = args["hsn"].(string)
+	filter.TradeName, _ = args["tradeName"].(string)
+	filter.CommercialName, _ = args["commercialName"].(string)
+	filter.Category, _ = args["category"].(string)
+	filter.FuelFamily, _ = args["fuelFamily"].(string)
+	filter.AllotmentDateFrom, _ = args["allotmentDateFrom"].(Date)
+	filter.AllotmentDateTo, _ = args["allotmentDateTo"].(Date)
+	filter.MinPower, _ = args["minPower"].(int)
+	filter.MaxPower, _ = args["maxPower"].(int)
+	filter.Offset, _ = args["offset"].(int)
+	if id, ok := args["powerSource"].(int); ok {
+		filter.PowerSourceID = &id
+	}
+	// a zero limit is no limit to the repository, so 'first' must not be null
+	// or zero
+	first, ok := args["first"].(int)
+	if !ok || first < 1 || first > graphQLMaxListSize {
+		return nil, fmt.Errorf("first must be between 1 and %d", graphQLMaxListSize)
+	}
+	filter.Limit = first
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("offset must not be negative")
+	}
+	if filter.FuelFamily != "" && !containsString(FuelFamilies, filter.FuelFamily) {
+		return nil, fmt.Errorf("fuel family must be one of %s", strings.Join(FuelFamilies, ", "))
+	}
+	return filter, nil
+}
+
+func (g *GraphQL) findVehicles(p graphql.ResolveParams, filter *VehicleFilter) (interface{}, error) {
+	vehicles, err := g.repositoryOf(p).FindVehicles(filter)
+	return graphQLResult(p, vehicles, err)
+}
+
+func (g *GraphQL) buildSchema() (graphql.Schema, error) {
+
+	manufacturerNameType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "ManufacturerName",
+		Description: "A name a manufacturer was registered under.",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"from": &graphql.Field{
+				Type:        graphQLDate,
+				Description: "The first allotment date under this name.",
+			},
+			"to": &graphql.Field{
+				Type:        graphQLDate,
+				Description: "The last allotment date under this name.",
+			},
+		},
+	})
+
+	powerSourceClassificationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PowerSourceClassification",
+		Description: "The classification of a power source.",
+		Fields: graphql.Fields{
+			"fuelFamily": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: fmt.Sprintf("The fuel family, one of %s.", strings.Join(FuelFamilies, ", ")),
+			},
+			"energyCarriers": &graphql.Field{
+				Type:        graphql.NewList(graphql.String),
+				Description: "The primary energy carriers, e.g. petrol and electricity.",
+			},
+			"electrified": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"hybridType": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The hybrid type, one of none, mild-full and plug-in.",
+			},
+			"fuelCell": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"bivalent": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.Boolean),
+				Description: "Whether it runs on either of two energy carriers.",
+			},
+		},
+	})
+
+	powerSourceType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PowerSource",
+		Description: "The power source of a vehicle.",
+		Fields: graphql.Fields{
+			"id":             &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"name":           &graphql.Field{Type: graphql.String},
+			"description":    &graphql.Field{Type: graphql.String},
+			"classification": &graphql.Field{Type: powerSourceClassificationType},
+		},
+	})
+
+	manufacturerType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Manufacturer",
+		Description: "A vehicle manufacturer.",
+		Fields: graphql.Fields{
+			"hsn":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"name": &graphql.Field{Type: graphql.String},
+			"names": &graphql.Field{
+				Type:        graphql.NewList(manufacturerNameType),
+				Description: "The names the manufacturer was registered under.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					m := p.Source.(*Manufacturer)
+					if m.Names != nil {
+						return m.Names, nil
+					}
+					m, err := g.repositoryOf(p).GetManufacturer(m.ID)
+					if err != nil {
+						return graphQLResult(p, nil, err)
+					}
+					return m.Names, nil
+				},
+			},
+		},
+	})
+
+	vehicleType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Vehicle",
+		Description: "A vehicle type.",
+		Fields: graphql.Fields{
+			"hsn": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*Vehicle).ManufacturerID, nil
+				},
+			},
+			"tsn":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"manufacturerName": &graphql.Field{Type: graphql.String},
+			"tradeName":        &graphql.Field{Type: graphql.String},
+			"commercialName":   &graphql.Field{Type: graphql.String},
+			"allotmentDate":    &graphql.Field{Type: graphQLDate},
+			"category":         &graphql.Field{Type: graphql.String},
+			"bodywork":         &graphql.Field{Type: graphql.String},
+			"power":            &graphql.Field{Type: graphql.Int},
+			"engineCapacity": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The engine capacity in cm³, null if unknown, e.g. for electric vehicles.",
+			},
+			"axles": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The number of axles, null if unknown.",
+			},
+			"poweredAxles": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The number of powered axles, null if unknown.",
+			},
+			"seats": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The number of seats, null if unknown.",
+			},
+			"maximumMass": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The technically permissible maximum mass in kg, null if unknown.",
+			},
+			"manufacturer": &graphql.Field{
+				Type: manufacturerType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					v := p.Source.(*Vehicle)
+					if v.Manufacturer != nil {
+						return v.Manufacturer, nil
+					}
+					return graphQLLoaderOf(p).manufacturer(p, v.ManufacturerID), nil
+				},
+			},
+			"powerSource": &graphql.Field{
+				Type: powerSourceType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					v := p.Source.(*Vehicle)
+					return graphQLLoaderOf(p).powerSource(p, v.PowerSourceID, graphQLContext(p).Translate())
+				},
+			},
+		},
+	})
+
+	manufacturerType.AddFieldConfig("vehicles", &graphql.Field{
+		Type:        graphql.NewList(vehicleType),
+		Description: "The vehicles of the manufacturer.",
+		Args:        graphQLVehicleFilterArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			filter, err := vehicleFilter(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			filter.ManufacturerID = p.Source.(*Manufacturer).ID
+			return g.findVehicles(p, filter)
+		},
+	})
+
+	powerSourceType.AddFieldConfig("vehicles", &graphql.Field{
+		Type:        graphql.NewList(vehicleType),
+		Description: "The vehicles using the power source.",
+		Args:        graphQLVehicleFilterArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			filter, err := vehicleFilter(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			filter.PowerSourceID = &p.Source.(*PowerSource).ID
+			return g.findVehicles(p, filter)
+		},
+	})
+
+	powerSourceType.AddFieldConfig("manufacturers", &graphql.Field{
+		Type:        graphql.NewList(manufacturerType),
+		Description: "The manufacturers of vehicles using the power source.",
+		Args: graphql.FieldConfigArgument{
+			"first": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 100,
+				Description:  fmt.Sprintf("The maximum number of manufacturers, from 1 to %d.", graphQLMaxListSize),
+			},
+			"offset": &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 0,
+				Description:  "The number of manufacturers to skip.",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			filter, err := vehicleFilter(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			manufacturers, err := g.repositoryOf(p).GetManufacturersByPowerSource(
+				p.Source.(*PowerSource), filter.Limit, filter.Offset)
+			return graphQLResult(p, manufacturers, err)
+		},
+	})
+
+	vehiclesArgs := graphql.FieldConfigArgument{
+		"hsn": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "The manufacturer code number.",
+		},
+		"powerSource": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The power source code.",
+		},
+	}
+	for name, arg := range graphQLVehicleFilterArgs {
+		vehiclesArgs[name] = arg
+	}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"manufacturers": &graphql.Field{
+				Type: graphql.NewList(manufacturerType),
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{
+						Type:        graphql.String,
+						Description: "Part of the manufacturer name, case insensitive.",
+					},
+					"first": &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: graphQLMaxListSize,
+						Description:  fmt.Sprintf("The maximum number of manufacturers, from 1 to %d.", graphQLMaxListSize),
+					},
+					"offset": &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: 0,
+						Description:  "The number of manufacturers to skip.",
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter, err := vehicleFilter(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					manufacturers, err := g.repositoryOf(p).GetManufacturers()
+					if err != nil {
+						return graphQLResult(p, nil, err)
+					}
+					name, _ := p.Args["name"].(string)
+					filtered := []*Manufacturer{}
+					for _, m := range manufacturers {
+						if name == "" || strings.Contains(strings.ToLower(m.Name), strings.ToLower(name)) {
+							filtered = append(filtered, m)
+						}
+					}
+					if filter.Offset >= len(filtered) {
+						return []*Manufacturer{}, nil
+					}
+					filtered = filtered[filter.Offset:]
+					if len(filtered) > filter.Limit {
+						filtered = filtered[:filter.Limit]
+					}
+					return filtered, nil
+				},
+			},
+			"manufacturer": &graphql.Field{
+				Type: manufacturerType,
+				Args: graphql.FieldConfigArgument{
+					"hsn": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					m, err := g.repositoryOf(p).GetManufacturer(p.Args["hsn"].(string))
+					return graphQLResult(p, m, err)
+				},
+			},
+			"vehicles": &graphql.Field{
+				Type: graphql.NewList(vehicleType),
+				Args: vehiclesArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter, err := vehicleFilter(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return g.findVehicles(p, filter)
+				},
+			},
+			"vehicle": &graphql.Field{
+				Type: vehicleType,
+				Args: graphql.FieldConfigArgument{
+					"hsn": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"tsn": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					m := &Manufacturer{ID: p.Args["hsn"].(string)}
+					v, err := g.repositoryOf(p).GetVehicle(m, p.Args["tsn"].(string), graphQLContext(p).Translate())
+					return graphQLResult(p, v, err)
+				},
+			},
+			"powerSources": &graphql.Field{
+				Type: graphql.NewList(powerSourceType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entities, err := g.repositoryOf(p).GetPowerSources(graphQLContext(p).Translate())
+					return graphQLResult(p, entities, err)
+				},
+			},
+			"powerSource": &graphql.Field{
+				Type: powerSourceType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					ps, err := g.repositoryOf(p).GetPowerSource(strconv.Itoa(p.Args["id"].(int)), graphQLContext(p).Translate())
+					return graphQLResult(p, ps, err)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
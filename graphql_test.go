@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func TestGraphQLLimits(t *testing.T) {
+
+	g, err := NewGraphQL(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		query     string
+		variables map[string]interface{}
+		valid     bool
+	}{
+		{`{ manufacturer(hsn: "0005") { name vehicles(first: 10) { tsn powerSource { name } } } }`, nil, true},
+		{`{ manufacturers { vehicles { manufacturer { vehicles { manufacturer { name } } } } } }`, nil, false},
+		{`{ manufacturer(hsn: "0005") { vehicles { manufacturer { vehicles { manufacturer { vehicles { manufacturer { vehicles { tsn } } } } } } } } }`, nil, false},
+		{`query($n: Int) { vehicles(first: $n) { tsn manufacturer { name } } }`, map[string]interface{}{"n": 1000.0}, true},
+		{`query($n: Int) { vehicles(first: $n) { tsn manufacturer { name vehicles(first: 10) { tsn } } } }`, map[string]interface{}{"n": 1000.0}, false},
+		{`{ vehicles(first: 100) { ...v } } fragment v on Vehicle { manufacturer { vehicles(first: 100) { tsn } } }`, nil, false},
+		{`{ vehicles(first: 0) { manufacturer { vehicles(first: 1000) { manufacturer { vehicles(first: 1000) { tsn } } } } } }`, nil, false},
+		{`{ manufacturers { hsn name } }`, nil, true},
+		{`{ manufacturers(first: 50) { hsn names { name from to } } }`, nil, true},
+		{`{ manufacturers { vehicles(first: 240) { hsn } } }`, nil, false},
+		{`{ manufacturers(first: 20) { vehicles(first: 240) { hsn } } }`, nil, true},
+		{`{ manufacturer(hsn: "0005") { vehicles { manufacturer { vehicles { hsn } } } } }`, nil, false},
+		{`{ powerSources { name vehicles(first: 10) { tsn } } }`, nil, true},
+	} {
+		document, err := parser.Parse(parser.ParseParams{Source: test.query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = g.checkLimits(document, "", test.variables)
+		if test.valid && err != nil {
+			t.Fatalf("query '%s' is rejected: %v", test.query, err)
+		}
+		if !test.valid && err == nil {
+			t.Fatalf("query '%s' is accepted", test.query)
+		}
+		t.Logf("%s: %v", test.query, err)
+	}
+}
+
+// countingRepository counts the queries of manufacturers and power sources.
+type countingRepository struct {
+	*MemoryRepository
+	manufacturerQueries int
+	powerSourceQueries  int
+}
+
+func (r *countingRepository) WithContext(context.Context) Repository {
+	return r
+}
+
+func (r *countingRepository) GetManufacturer(id string) (*Manufacturer, error) {
+	r.manufacturerQueries++
+	return r.MemoryRepository.GetManufacturer(id)
+}
+
+func (r *countingRepository) GetManufacturersByIDs(ids []string) ([]*Manufacturer, error) {
+	r.manufacturerQueries++
+	return r.MemoryRepository.GetManufacturersByIDs(ids)
+}
+
+func (r *countingRepository) GetPowerSources(language string) ([]*PowerSource, error) {
+	r.powerSourceQueries++
+	return r.MemoryRepository.GetPowerSources(language)
+}
+
+func (r *countingRepository) GetPowerSource(id string, language string) (*PowerSource, error) {
+	r.powerSourceQueries++
+	return r.MemoryRepository.GetPowerSource(id, language)
+}
+
+func TestGraphQLQuery(t *testing.T) {
+
+	repository := &countingRepository{MemoryRepository: NewTestMemoryRepository(t)}
+	g, err := NewGraphQL(repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer()
+	server.Post("/graphql", g.Query)
+
+	query := func(query string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"query": query})
+		req, err := http.NewRequest("POST", "/graphql", strings.NewReader(string(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		AssertOkStatusCode(t, rr.Code)
+		return rr
+	}
+
+	t.Log("query vehicles with their manufacturers and power sources")
+	rr := query(`{ vehicles(first: 100) { tsn manufacturer { hsn names { name } } powerSource { name } } }`)
+	var result struct {
+		Data struct {
+			Vehicles []struct {
+				Manufacturer *struct {
+					HSN   string        `json:"hsn"`
+					Names []interface{} `json:"names"`
+				} `json:"manufacturer"`
+				PowerSource *struct {
+					Name string `json:"name"`
+				} `json:"powerSource"`
+			} `json:"vehicles"`
+		} `json:"data"`
+		Errors []interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Errors) > 0 || len(result.Data.Vehicles) != 100 {
+		t.Fatalf("result is bad, got:'%v'", rr.Body.String())
+	}
+	for _, v := range result.Data.Vehicles {
+		if v.Manufacturer == nil || len(v.Manufacturer.Names) == 0 || v.PowerSource == nil || v.PowerSource.Name == "" {
+			t.Fatalf("vehicle is bad, got:'%v'", v)
+		}
+	}
+	if repository.manufacturerQueries != 1 || repository.powerSourceQueries != 1 {
+		t.Fatalf("queries are bad, got:'%v, %v', want:'%v, %v'",
+			repository.manufacturerQueries, repository.powerSourceQueries, 1, 1)
+	}
+
+	t.Log("query no vehicles")
+	rr = query(`{ vehicles(first: 0) { tsn } }`)
+	if !strings.Contains(rr.Body.String(), "first must be between 1 and 1000") {
+		t.Fatalf("result is bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("query page of manufacturers")
+	rr = query(`{ manufacturers(first: 2, offset: 1) { hsn } }`)
+	want := `{"data":{"manufacturers":[{"hsn":"0007"},{"hsn":"0009"}]}}`
+	if strings.TrimSpace(rr.Body.String()) != want {
+		t.Fatalf("result is bad, got:'%v', want:'%v'", rr.Body.String(), want)
+	}
+}
+
+func TestGraphQLListSizes(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("check list sizes against the dataset")
+	names := 0
+	for _, m := range r.manufacturers {
+		if len(m.Names) > names {
+			names = len(m.Names)
+		}
+	}
+	powerSources, err := r.GetPowerSources(DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names > graphQLListSizes["Manufacturer.names"] || len(powerSources) > graphQLListSizes["Query.powerSources"] {
+		t.Fatalf("list sizes are bad, got:'%v, %v', want:'%v'", names, len(powerSources), graphQLListSizes)
+	}
+}
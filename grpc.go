@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/enviroCar/vehicles/vehiclespb"
+	uuid "github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ vehiclespb.VehiclesServer = (*GRPCServer)(nil)
+
+// GRPCServer is the gRPC server of the vehicle service. Its methods query the
+// repository bound to the context of the call, so that the queries are traced
+// as children of the call and canceled with it.
+type GRPCServer struct {
+	vehiclespb.UnimplementedVehiclesServer
+	repository Repository
+	server     *grpc.Server
+	logger     *logrus.Logger
+}
+
+// NewGRPCServer creates a new GRPCServer.
+func NewGRPCServer(repository Repository, logger *logrus.Logger, options ...grpc.ServerOption) *GRPCServer {
+	s := &GRPCServer{
+		repository: repository,
+		logger:     logger,
+	}
+	options = append(options, grpc.UnaryInterceptor(s.loggingInterceptor))
+	s.server = grpc.NewServer(options...)
+	vehiclespb.RegisterVehiclesServer(s.server, s)
+	return s
+}
+
+// Start starts the server.
+func (s *GRPCServer) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Printf("Serving gRPC on %v\n", addr)
+	return s.server.Serve(listener)
+}
+
+// Stop stops the server.
+func (s *GRPCServer) Stop() {
+	s.server.GracefulStop()
+}
+
+// grpcLoggerKey is the context key of the logger of a call.
+type grpcLoggerKey struct{}
+
+// loggingInterceptor traces the call as child of the propagated trace context
+// and logs it with the request id of the 'x-request-id' metadata, or a new
+// one, like the HTTP handler does. The request id is sent back as header.
+func (s *GRPCServer) loggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	log.Printf("gRPC %v", info.FullMethod)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestId := ""
+	if values := md.Get("x-request-id"); len(values) > 0 {
+		requestId = values[0]
+	}
+	if requestId == "" {
+		requestId = uuid.NewV4().String()
+	}
+
+	ctx = propagator.Extract(ctx, metadataCarrier(md))
+	ctx, span := tracer.Start(ctx, info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	fields := logrus.Fields{"request-id": requestId}
+	if span.SpanContext().HasTraceID() {
+		fields["trace-id"] = span.SpanContext().TraceID().String()
+	}
+	ctx = context.WithValue(ctx, grpcLoggerKey{}, s.logger.WithFields(fields))
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", requestId))
+
+	res, err := handler(ctx, req)
+	if status.Code(err) == codes.Internal {
+		span.SetStatus(otelcodes.Error, ErrInternalServer.Error())
+	}
+	return res, err
+}
+
+// metadataCarrier propagates the trace context by the metadata of a call.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// loggerOf returns the logger of the call.
+func (s *GRPCServer) loggerOf(ctx context.Context) *logrus.Entry {
+	if logger, ok := ctx.Value(grpcLoggerKey{}).(*logrus.Entry); ok {
+		return logger
+	}
+	return logrus.NewEntry(s.logger)
+}
+
+// error maps the error to a gRPC status error.
+func (s *GRPCServer) error(ctx context.Context, err error, msg string) error {
+	if e, ok := err.(Error); ok {
+		switch e.Status() {
+		case http.StatusNotFound:
+			return status.Error(codes.NotFound, e.Error())
+		case http.StatusBadRequest:
+			return status.Error(codes.InvalidArgument, e.Error())
+		}
+	}
+	s.loggerOf(ctx).WithError(err).Error(msg)
+	return status.Error(codes.Internal, ErrInternalServer.Error())
+}
+
+// grpcLanguage returns the supported language of the request or the
+// DefaultLanguage.
+func grpcLanguage(language string) string {
+	if language = primaryLanguage(language); isSupportedLanguage(language) {
+		return language
+	}
+	return DefaultLanguage
+}
+
+// GetManufacturers returns all manufacturers.
+func (s *GRPCServer) GetManufacturers(ctx context.Context, req *vehiclespb.GetManufacturersRequest) (*vehiclespb.GetManufacturersResponse, error) {
+	repository := s.repository.WithContext(ctx)
+	entities, err := repository.GetManufacturers()
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get manufacturers")
+	}
+	res := &vehiclespb.GetManufacturersResponse{}
+	for _, m := range entities {
+		res.Manufacturers = append(res.Manufacturers, manufacturerToProto(m))
+	}
+	return res, nil
+}
+
+// GetManufacturer returns the specified manufacturer.
+func (s *GRPCServer) GetManufacturer(ctx context.Context, req *vehiclespb.GetManufacturerRequest) (*vehiclespb.Manufacturer, error) {
+	repository := s.repository.WithContext(ctx)
+	m, err := repository.GetManufacturer(req.GetHsn())
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get manufacturer")
+	}
+	return manufacturerToProto(m), nil
+}
+
+// GetVehicles returns all vehicles of the manufacturer.
+func (s *GRPCServer) GetVehicles(ctx context.Context, req *vehiclespb.GetVehiclesRequest) (*vehiclespb.GetVehiclesResponse, error) {
+	repository := s.repository.WithContext(ctx)
+	m, err := repository.GetManufacturer(req.GetHsn())
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get manufacturer")
+	}
+	// The full vehicles are loaded, as unset optional fields mean unknown.
+	vehicles, err := repository.FindVehicles(&VehicleFilter{ManufacturerID: m.ID})
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get vehicles")
+	}
+	res := &vehiclespb.GetVehiclesResponse{}
+	for _, v := range vehicles {
+		res.Vehicles = append(res.Vehicles, vehicleToProto(v))
+	}
+	return res, nil
+}
+
+// GetVehicle returns the specified vehicle.
+func (s *GRPCServer) GetVehicle(ctx context.Context, req *vehiclespb.GetVehicleRequest) (*vehiclespb.Vehicle, error) {
+	repository := s.repository.WithContext(ctx)
+	m, err := repository.GetManufacturer(req.GetHsn())
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get manufacturer")
+	}
+	v, err := repository.GetVehicle(m, req.GetTsn(), grpcLanguage(req.GetLanguage()))
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get vehicle")
+	}
+	return vehicleToProto(v), nil
+}
+
+// GetPowerSources returns all power sources.
+func (s *GRPCServer) GetPowerSources(ctx context.Context, req *vehiclespb.GetPowerSourcesRequest) (*vehiclespb.GetPowerSourcesResponse, error) {
+	repository := s.repository.WithContext(ctx)
+	entities, err := repository.GetPowerSources(grpcLanguage(req.GetLanguage()))
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get power sources")
+	}
+	res := &vehiclespb.GetPowerSourcesResponse{}
+	for _, ps := range entities {
+		res.PowerSources = append(res.PowerSources, powerSourceToProto(ps))
+	}
+	return res, nil
+}
+
+// GetPowerSource returns the specified power source.
+func (s *GRPCServer) GetPowerSource(ctx context.Context, req *vehiclespb.GetPowerSourceRequest) (*vehiclespb.PowerSource, error) {
+	repository := s.repository.WithContext(ctx)
+	ps, err := repository.GetPowerSource(strconv.Itoa(int(req.GetId())), grpcLanguage(req.GetLanguage()))
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get power source")
+	}
+	return powerSourceToProto(ps), nil
+}
+
+// GetVIN decodes the VIN and returns the candidate manufacturers.
+func (s *GRPCServer) GetVIN(ctx context.Context, req *vehiclespb.GetVINRequest) (*vehiclespb.VIN, error) {
+	vin, err := ParseVIN(req.GetVin())
+	if err != nil {
+		return nil, s.error(ctx, err, "could not parse vin")
+	}
+	repository := s.repository.WithContext(ctx)
+	vin.WMI, err = repository.GetWMI(vin.WMI.ID)
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get wmi")
+	}
+	vin.Manufacturers, err = repository.GetManufacturersByWMI(vin.WMI)
+	if err != nil {
+		return nil, s.error(ctx, err, "could not get manufacturers by wmi")
+	}
+	return vinToProto(vin), nil
+}
+
+func manufacturerToProto(m *Manufacturer) *vehiclespb.Manufacturer {
+	if m == nil {
+		return nil
+	}
+	pb := &vehiclespb.Manufacturer{
+		Hsn:  m.ID,
+		Name: m.Name,
+	}
+	for _, name := range m.Names {
+		pb.Names = append(pb.Names, &vehiclespb.ManufacturerName{
+			Name: name.Name,
+			From: name.ValidFrom.String(),
+			To:   name.ValidTo.String(),
+		})
+	}
+	return pb
+}
+
+func vehicleToProto(v *Vehicle) *vehiclespb.Vehicle {
+	return &vehiclespb.Vehicle{
+		Hsn:              v.ManufacturerID,
+		Tsn:              v.TSN,
+		ManufacturerName: v.ManufacturerName,
+		TradeName:        v.TradeName,
+		CommercialName:   v.CommercialName,
+		AllotmentDate:    v.AllotmentDate.String(),
+		Category:         v.Category,
+		Bodywork:         v.Bodywork,
+		Power:            int32(v.Power),
+		EngineCapacity:   nullInt32(v.EngineCapacity),
+		Axles:            nullInt32(v.Axles),
+		PoweredAxles:     nullInt32(v.PoweredAxles),
+		Seats:            nullInt32(v.Seats),
+		MaximumMass:      nullInt32(v.MaximumMass),
+		PowerSourceId:    int32(v.PowerSourceID),
+		PowerSource:      powerSourceToProto(v.PowerSource),
+		Manufacturer:     manufacturerToProto(v.Manufacturer),
+	}
+}
+
+// nullInt32 converts a nullable attribute to an optional field.
+func nullInt32(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+	n := int32(*value)
+	return &n
+}
+
+func powerSourceToProto(ps *PowerSource) *vehiclespb.PowerSource {
+	if ps == nil {
+		return nil
+	}
+	return &vehiclespb.PowerSource{
+		Id:             int32(ps.ID),
+		Name:           ps.ShortName,
+		Description:    ps.Description,
+		Classification: powerSourceClassificationToProto(ps.Classification),
+	}
+}
+
+func powerSourceClassificationToProto(c *PowerSourceClassification) *vehiclespb.PowerSourceClassification {
+	if c == nil {
+		return nil
+	}
+	return &vehiclespb.PowerSourceClassification{
+		FuelFamily:     c.FuelFamily,
+		EnergyCarriers: c.EnergyCarriers,
+		Electrified:    c.Electrified,
+		HybridType:     c.HybridType,
+		FuelCell:       c.FuelCell,
+		Bivalent:       c.Bivalent,
+	}
+}
+
+func vinToProto(vin *VIN) *vehiclespb.VIN {
+	pb := &vehiclespb.VIN{
+		Vin: vin.VIN,
+		Wmi: &vehiclespb.WMI{
+			Wmi:     vin.WMI.ID,
+			Name:    vin.WMI.Name,
+			Country: vin.WMI.Country,
+		},
+		Vds:             vin.VDS,
+		Vis:             vin.VIS,
+		CheckDigitValid: vin.CheckDigitValid,
+	}
+	for _, year := range vin.ModelYears {
+		pb.ModelYears = append(pb.ModelYears, int32(year))
+	}
+	for _, m := range vin.Manufacturers {
+		pb.Manufacturers = append(pb.Manufacturers, manufacturerToProto(m))
+	}
+	return pb
+}
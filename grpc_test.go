@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/enviroCar/vehicles/vehiclespb"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func NewTestGRPCClient(t *testing.T, repository Repository) (vehiclespb.VehiclesClient, func()) {
+	return NewTestGRPCClientWithLogger(t, repository, logrus.New())
+}
+
+func NewTestGRPCClientWithLogger(t *testing.T, repository Repository, logger *logrus.Logger) (vehiclespb.VehiclesClient, func()) {
+	t.Log("init new grpc server")
+	server := NewGRPCServer(repository, logger)
+	listener := bufconn.Listen(1 << 20)
+	go server.server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vehiclespb.NewVehiclesClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func TestGRPCGetVINInvalid(t *testing.T) {
+
+	client, closeClient := NewTestGRPCClient(t, nil)
+	defer closeClient()
+
+	t.Log("get invalid vin")
+	_, err := client.GetVIN(context.Background(), &vehiclespb.GetVINRequest{Vin: "1M8GDM9A1KP042788"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestGRPCGetVehicle(t *testing.T) {
+
+	client, closeClient := NewTestGRPCClient(t, NewTestRepository(t))
+	defer closeClient()
+
+	t.Log("get vehicle by manufacturer and vehicle id")
+	v, err := client.GetVehicle(context.Background(), &vehiclespb.GetVehicleRequest{Hsn: "0005", Tsn: "155", Language: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.GetPowerSource().GetName() != "Petrol" {
+		t.Fatalf("power source is bad, got:'%v', want:'%v'", v.GetPowerSource().GetName(), "Petrol")
+	}
+	t.Log(v)
+}
+
+func TestGRPCGetManufacturerNotFound(t *testing.T) {
+
+	client, closeClient := NewTestGRPCClient(t, NewTestRepository(t))
+	defer closeClient()
+
+	t.Log("get manufacturer")
+	_, err := client.GetManufacturer(context.Background(), &vehiclespb.GetManufacturerRequest{Hsn: "000x"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", status.Code(err), codes.NotFound)
+	}
+}
+
+// failingRepository fails to get the manufacturers.
+type failingRepository struct{ Repository }
+
+func (r failingRepository) WithContext(context.Context) Repository {
+	return r
+}
+
+func (failingRepository) GetManufacturers() ([]*Manufacturer, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestGRPCErrorRequestID(t *testing.T) {
+
+	logger, hook := logtest.NewNullLogger()
+	client, closeClient := NewTestGRPCClientWithLogger(t, failingRepository{}, logger)
+	defer closeClient()
+
+	t.Log("get manufacturers failing with request id")
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "4711")
+	var header metadata.MD
+	_, err := client.GetManufacturers(ctx, &vehiclespb.GetManufacturersRequest{}, grpc.Header(&header))
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", status.Code(err), codes.Internal)
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "4711" {
+		t.Fatalf("request id header is bad, got:'%v', want:'%v'", got, "4711")
+	}
+	entry := hook.LastEntry()
+	if entry == nil || entry.Data["request-id"] != "4711" {
+		t.Fatalf("logged request id is bad, got:'%v', want:'%v'", entry, "4711")
+	}
+}
+
+// tracedRepository traces getting a manufacturer as child of the context it is
+// bound to, like the query hook of the PostgresRepository, and requires the
+// context to have a deadline.
+type tracedRepository struct {
+	*MemoryRepository
+	ctx context.Context
+}
+
+func (r *tracedRepository) WithContext(ctx context.Context) Repository {
+	return &tracedRepository{r.MemoryRepository, ctx}
+}
+
+func (r *tracedRepository) GetManufacturer(id string) (*Manufacturer, error) {
+	if r.ctx == nil {
+		return nil, errors.New("repository is not bound to a context")
+	}
+	_, span := tracer.Start(r.ctx, "SELECT")
+	defer span.End()
+	if _, ok := r.ctx.Deadline(); !ok {
+		return nil, errors.New("context has no deadline")
+	}
+	return r.MemoryRepository.GetManufacturer(id)
+}
+
+func TestGRPCTracing(t *testing.T) {
+
+	collector, provider := newTestTracing(t)
+
+	client, closeClient := NewTestGRPCClient(t, &tracedRepository{MemoryRepository: NewTestMemoryRepository(t)})
+	defer closeClient()
+
+	t.Log("get manufacturer with deadline")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.GetManufacturer(ctx, &vehiclespb.GetManufacturerRequest{Hsn: "0005"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("export spans")
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	call, ok := collector.spans[vehiclespb.Vehicles_GetManufacturer_FullMethodName]
+	if !ok {
+		t.Fatalf("call span is missing, got:'%v'", collector.spans)
+	}
+	query, ok := collector.spans["SELECT"]
+	if !ok {
+		t.Fatalf("query span is missing, got:'%v'", collector.spans)
+	}
+	if string(query.ParentSpanId) != string(call.SpanId) {
+		t.Fatalf("query span parent is bad, got:'%x', want:'%x'", query.ParentSpanId, call.SpanId)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HealthPath is the path of the health check on the health listener.
+const HealthPath = "/health"
+
+// healthHandler responds to the health check. It is served by a plain HTTP
+// listener, so that probes need neither the TLS configuration nor a client
+// certificate of the API.
+func healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintln(w, "OK")
+	})
+	return mux
+}
+
+// startHealthServer serves the health check on the address.
+func startHealthServer(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           healthHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+		WriteTimeout:      5 * time.Second,
+	}
+	log.Printf("Serving health check on %v\n", addr)
+	return server.ListenAndServe()
+}
+
+// healthURL returns the URL of the health check listening on the address,
+// addressing the local host if the address has no host.
+func healthURL(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", err
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + HealthPath, nil
+}
+
+// checkHealth requests the health check at the URL and returns an error
+// unless it succeeds.
+func checkHealth(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: %s", resp.Status)
+	}
+	return nil
+}
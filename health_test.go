@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthCheck(t *testing.T) {
+
+	t.Log("check health of the health listener")
+	ts := httptest.NewServer(healthHandler())
+	defer ts.Close()
+	if err := checkHealth(context.Background(), ts.URL+HealthPath); err != nil {
+		t.Fatalf("health check is bad, got:'%v'", err)
+	}
+
+	t.Log("check health of an unknown path")
+	if err := checkHealth(context.Background(), ts.URL+"/"); err == nil {
+		t.Fatalf("health check is bad, got:'%v', want an error", err)
+	}
+
+	t.Log("post to the health check")
+	resp, err := http.Post(ts.URL+HealthPath, "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("status is bad, got:'%v', want:'%v'", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHealthURL(t *testing.T) {
+	for addr, want := range map[string]string{
+		":8081":          "http://localhost:8081/health",
+		"0.0.0.0:8081":   "http://localhost:8081/health",
+		"127.0.0.1:9000": "http://127.0.0.1:9000/health",
+		"[::1]:8081":     "http://[::1]:8081/health",
+	} {
+		t.Logf("health URL of '%s'", addr)
+		if got, err := healthURL(addr); err != nil || got != want {
+			t.Fatalf("URL is bad, got:'%v' %v, want:'%v'", got, err, want)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Media types of the representations of resources.
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeHAL     = "application/hal+json"
+	MediaTypeJSONAPI = "application/vnd.api+json"
+)
+
+// negotiateMediaType selects the media type by the Accept header among the
+// media types, that are in order of preference. It falls back to the first
+// media type if none is acceptable.
+func negotiateMediaType(r *http.Request, mediaTypes []string) string {
+	weights := map[string]float64{}
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		weights[mediaType] = weight
+	}
+
+	selected, selectedWeight := mediaTypes[0], 0.0
+	for _, mediaType := range mediaTypes {
+		weight, ok := weights[mediaType]
+		if !ok {
+			weight, ok = weights[strings.Split(mediaType, "/")[0]+"/*"]
+		}
+		if !ok {
+			weight, ok = weights["*/*"]
+		}
+		if ok && weight > selectedWeight {
+			selected, selectedWeight = mediaType, weight
+		}
+	}
+	return selected
+}
+
+// mediaTypes returns the media types the content can be represented in, in
+// order of preference.
+func mediaTypes(content interface{}) []string {
+	if r, ok := content.(representable); ok {
+		return r.MediaTypes()
+	}
+	mediaTypes := []string{MediaTypeJSON, MediaTypeHAL}
+	if isJSONAPIContent(content) {
+		mediaTypes = append(mediaTypes, MediaTypeJSONAPI)
+	}
+	if isLinkedDataContent(content) {
+		mediaTypes = append(mediaTypes, MediaTypeJSONLD)
+	}
+	return mediaTypes
+}
+
+// representable is content with representations of its own, e.g. a Dataset.
+type representable interface {
+	MediaTypes() []string
+	Represent(mediaType string) ([]byte, error)
+}
+
+// represent encodes the content in the media type. Collections are
+// identified by the URL of the request, that is self.
+func represent(content interface{}, mediaType, self string) ([]byte, error) {
+	if r, ok := content.(representable); ok {
+		return r.Represent(mediaType)
+	}
+	switch mediaType {
+	case MediaTypeHAL:
+		return encodeHAL(content, self)
+	case MediaTypeJSONAPI:
+		return encodeJSONAPI(content, self)
+	case MediaTypeJSONLD:
+		return encodeJSONLD(content)
+	}
+	return encodeJSON(content)
+}
+
+func encodeJSON(content interface{}) ([]byte, error) {
+	bytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, '\n'), nil
+}
+
+// encodeHAL encodes the content as HAL. The links of Linked objects become
+// '_links' by relation and nested Linked objects become '_embedded'. A
+// collection is embedded into a resource linking to itself, named by the last
+// segment of its path.
+func encodeHAL(content interface{}, self string) ([]byte, error) {
+	bytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	value = halValue(value)
+	if items, ok := value.([]interface{}); ok {
+		name := path.Base(strings.SplitN(self, "?", 2)[0])
+		if name == "." || name == "/" {
+			name = "items"
+		}
+		value = map[string]interface{}{
+			"_links":    map[string]interface{}{"self": map[string]string{"href": self}},
+			"_embedded": map[string]interface{}{name: items},
+		}
+	}
+	return encodeJSON(value)
+}
+
+func halValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case []interface{}:
+		for i, element := range value {
+			value[i] = halValue(element)
+		}
+		return value
+	case map[string]interface{}:
+		object := map[string]interface{}{}
+		embedded := map[string]interface{}{}
+		for key, element := range value {
+			switch {
+			case key == "links":
+				object["_links"] = halLinks(element)
+			case isLinkedValue(element):
+				embedded[key] = halValue(element)
+			default:
+				object[key] = halValue(element)
+			}
+		}
+		if len(embedded) > 0 {
+			object["_embedded"] = embedded
+		}
+		return object
+	}
+	return value
+}
+
+// halLinks groups the decoded links by relation, as an array if there are
+// several links of the relation.
+func halLinks(value interface{}) map[string]interface{} {
+	links := map[string]interface{}{}
+	elements, _ := value.([]interface{})
+	for _, element := range elements {
+		link, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		relation, _ := link["rel"].(string)
+		delete(link, "rel")
+		switch existing := links[relation].(type) {
+		case nil:
+			links[relation] = link
+		case []interface{}:
+			links[relation] = append(existing, link)
+		default:
+			links[relation] = []interface{}{existing, link}
+		}
+	}
+	return links
+}
+
+// isLinkedValue reports whether the decoded value is a Linked object with
+// links or a non-empty array of them.
+func isLinkedValue(value interface{}) bool {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		_, ok := value["links"]
+		return ok
+	case []interface{}:
+		for _, element := range value {
+			if !isLinkedValue(element) {
+				return false
+			}
+		}
+		return len(value) > 0
+	}
+	return false
+}
+
+// identified is a resource identified by its type and its id in JSON:API
+// documents.
+type identified interface {
+	identity() (string, string)
+}
+
+var identifiedType = reflect.TypeOf((*identified)(nil)).Elem()
+
+// isJSONAPIContent reports whether the content is a JSON:API resource, a
+// collection of them or a Linked object only.
+func isJSONAPIContent(content interface{}) bool {
+	switch content.(type) {
+	case identified, *Linked:
+		return true
+	}
+	t := reflect.TypeOf(content)
+	return t != nil && t.Kind() == reflect.Slice && t.Elem().Implements(identifiedType)
+}
+
+type jsonAPIDocument struct {
+	Data     interface{}             `json:"data"`
+	Included []*jsonAPIResource      `json:"included,omitempty"`
+	Links    map[string]*jsonAPILink `json:"links,omitempty"`
+}
+
+type jsonAPIResource struct {
+	Type          string                          `json:"type"`
+	ID            string                          `json:"id"`
+	Attributes    map[string]json.RawMessage      `json:"attributes,omitempty"`
+	Relationships map[string]*jsonAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]*jsonAPILink         `json:"links,omitempty"`
+}
+
+type jsonAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type jsonAPIRelationship struct {
+	Data interface{} `json:"data"`
+}
+
+type jsonAPILink struct {
+	URL       string `json:"href"`
+	MediaType string `json:"type,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// jsonAPILinks keys the links by relation, numbering further links of the
+// same relation, e.g. 'vehicle-2'.
+func jsonAPILinks(links []*Link) map[string]*jsonAPILink {
+	if len(links) == 0 {
+		return nil
+	}
+	keyed := map[string]*jsonAPILink{}
+	for _, link := range links {
+		key := link.Relation
+		for n := 2; keyed[key] != nil; n++ {
+			key = fmt.Sprintf("%s-%d", link.Relation, n)
+		}
+		keyed[key] = &jsonAPILink{URL: link.URL.String(), MediaType: link.MediaType, Title: link.Title}
+	}
+	return keyed
+}
+
+// jsonAPIEncoder collects the resources included into a JSON:API document.
+type jsonAPIEncoder struct {
+	included []*jsonAPIResource
+	seen     map[jsonAPIIdentifier]bool
+}
+
+// encodeJSONAPI encodes the content as a JSON:API document. Identified
+// resources nested into resources become relationships, that are included.
+func encodeJSONAPI(content interface{}, self string) ([]byte, error) {
+	e := &jsonAPIEncoder{seen: map[jsonAPIIdentifier]bool{}}
+	document := &jsonAPIDocument{}
+
+	switch content := content.(type) {
+	case *Linked:
+		document.Links = jsonAPILinks(content.Links)
+	case identified:
+		e.seen[identifier(content)] = true
+		resource, err := e.resource(content)
+		if err != nil {
+			return nil, err
+		}
+		document.Data = resource
+	default:
+		v := reflect.ValueOf(content)
+		for i := 0; i < v.Len(); i++ {
+			e.seen[identifier(v.Index(i).Interface().(identified))] = true
+		}
+		data := make([]*jsonAPIResource, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			resource, err := e.resource(v.Index(i).Interface().(identified))
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, resource)
+		}
+		document.Data = data
+		document.Links = map[string]*jsonAPILink{"self": {URL: self}}
+	}
+	document.Included = e.included
+	return encodeJSON(document)
+}
+
+func identifier(r identified) jsonAPIIdentifier {
+	t, id := r.identity()
+	return jsonAPIIdentifier{t, id}
+}
+
+func (e *jsonAPIEncoder) resource(r identified) (*jsonAPIResource, error) {
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &attributes); err != nil {
+		return nil, err
+	}
+	var links []*Link
+	if raw, ok := attributes["links"]; ok {
+		if err := json.Unmarshal(raw, &links); err != nil {
+			return nil, err
+		}
+	}
+	delete(attributes, "links")
+	delete(attributes, "id")
+	delete(attributes, "type")
+
+	id := identifier(r)
+	resource := &jsonAPIResource{Type: id.Type, ID: id.ID, Links: jsonAPILinks(links)}
+	relationships := relatedResources(r)
+	names := make([]string, 0, len(relationships))
+	for name := range relationships {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		related := relationships[name]
+		delete(attributes, name)
+		if resource.Relationships == nil {
+			resource.Relationships = map[string]*jsonAPIRelationship{}
+		}
+		switch related := related.(type) {
+		case identified:
+			resource.Relationships[name] = &jsonAPIRelationship{Data: identifier(related)}
+			if err := e.include(related); err != nil {
+				return nil, err
+			}
+		case []identified:
+			identifiers := make([]jsonAPIIdentifier, 0, len(related))
+			for _, other := range related {
+				identifiers = append(identifiers, identifier(other))
+				if err := e.include(other); err != nil {
+					return nil, err
+				}
+			}
+			resource.Relationships[name] = &jsonAPIRelationship{Data: identifiers}
+		}
+	}
+	if len(attributes) > 0 {
+		resource.Attributes = attributes
+	}
+	return resource, nil
+}
+
+func (e *jsonAPIEncoder) include(r identified) error {
+	id := identifier(r)
+	if e.seen[id] {
+		return nil
+	}
+	e.seen[id] = true
+	resource, err := e.resource(r)
+	if err != nil {
+		return err
+	}
+	e.included = append(e.included, resource)
+	return nil
+}
+
+// relatedResources returns the identified resources of the exported fields of
+// the resource by their JSON names, either a single resource or a slice.
+func relatedResources(r identified) map[string]interface{} {
+	related := map[string]interface{}{}
+	v := reflect.Indirect(reflect.ValueOf(r))
+	if v.Kind() != reflect.Struct {
+		return related
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.PkgPath != "" || field.Anonymous || name == "" || name == "-" {
+			continue
+		}
+		value := v.Field(i)
+		switch {
+		case value.Kind() == reflect.Ptr && !value.IsNil() && value.Type().Implements(identifiedType):
+			related[name] = value.Interface().(identified)
+		case value.Kind() == reflect.Slice && value.Len() > 0 && value.Type().Elem().Implements(identifiedType):
+			resources := make([]identified, 0, value.Len())
+			for j := 0; j < value.Len(); j++ {
+				resources = append(resources, value.Index(j).Interface().(identified))
+			}
+			related[name] = resources
+		}
+	}
+	return related
+}
+
+// jsonAPIErrors returns the JSON:API document of the error.
+func jsonAPIErrors(status int, err error) interface{} {
+	type jsonAPIError struct {
+		Status string `json:"status"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	}
+	return &struct {
+		Errors []*jsonAPIError `json:"errors"`
+	}{
+		Errors: []*jsonAPIError{{strconv.Itoa(status), http.StatusText(status), err.Error()}},
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+
+	offers := []string{MediaTypeJSON, MediaTypeHAL, MediaTypeJSONAPI}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", MediaTypeJSON},
+		{"*/*", MediaTypeJSON},
+		{"application/hal+json", MediaTypeHAL},
+		{"application/vnd.api+json, application/json;q=0.5", MediaTypeJSONAPI},
+		{"application/*;q=0.8, application/hal+json", MediaTypeHAL},
+		{"text/csv", MediaTypeJSON},
+	}
+	for _, test := range tests {
+		t.Logf("negotiate media type: '%s'", test.accept)
+		req, err := http.NewRequest("GET", "/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", test.accept)
+		if got := negotiateMediaType(req, offers); got != test.want {
+			t.Fatalf("media type is bad, got:'%v', want:'%v'", got, test.want)
+		}
+	}
+}
+
+func TestServerHypermedia(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+	server.Get("/powerSources/{id}", service.GetPowerSource)
+
+	get := func(path, accept string, v interface{}) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		req.Header.Set("Accept", accept)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		if got := rr.Header().Get("Content-Type"); got != accept {
+			t.Fatalf("content type is bad, got:'%v', want:'%v'", got, accept)
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), v); err != nil {
+			t.Fatal(err)
+		}
+		return rr
+	}
+
+	type halLink struct {
+		Href  string `json:"href"`
+		Type  string `json:"type"`
+		Title string `json:"title"`
+	}
+
+	t.Log("get HAL manufacturer")
+	var manufacturer struct {
+		Links map[string]*halLink `json:"_links"`
+		HSN   string              `json:"hsn"`
+	}
+	rr := get("/manufacturers/0005", MediaTypeHAL, &manufacturer)
+	AssertOkStatusCode(t, rr.Code)
+	if self := manufacturer.Links["self"]; self == nil || self.Href != "http://processing.envirocar.org/manufacturers/0005" || manufacturer.HSN != "0005" {
+		t.Fatalf("HAL manufacturer is bad, got:'%v'", rr.Body.String())
+	}
+	if manufacturer.Links["vehicles"] == nil || manufacturer.Links["models"] == nil {
+		t.Fatalf("HAL links are bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get HAL vehicles with embedded manufacturer")
+	var vehicles struct {
+		Links    map[string]*halLink `json:"_links"`
+		Embedded struct {
+			Vehicles []struct {
+				Links    map[string]*halLink `json:"_links"`
+				TSN      string              `json:"tsn"`
+				Embedded struct {
+					Manufacturer *struct {
+						Links map[string]*halLink `json:"_links"`
+						HSN   string              `json:"hsn"`
+					} `json:"manufacturer"`
+				} `json:"_embedded"`
+			} `json:"vehicles"`
+		} `json:"_embedded"`
+	}
+	rr = get("/manufacturers/0005/vehicles?embed=manufacturer", MediaTypeHAL, &vehicles)
+	AssertOkStatusCode(t, rr.Code)
+	if self := vehicles.Links["self"]; self == nil || self.Href != "http://processing.envirocar.org/manufacturers/0005/vehicles?embed=manufacturer" {
+		t.Fatalf("HAL collection is bad, got:'%v'", vehicles.Links)
+	}
+	if len(vehicles.Embedded.Vehicles) == 0 {
+		t.Fatalf("HAL vehicles are empty, got:'%v'", rr.Body.String())
+	}
+	v := vehicles.Embedded.Vehicles[0]
+	if v.TSN == "" || v.Links["canonical"] == nil || v.Embedded.Manufacturer == nil ||
+		v.Embedded.Manufacturer.HSN != "0005" || v.Embedded.Manufacturer.Links["canonical"] == nil {
+		t.Fatalf("HAL vehicle is bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get JSON:API vehicles with included power sources")
+	var document struct {
+		Data []struct {
+			Type          string                     `json:"type"`
+			ID            string                     `json:"id"`
+			Attributes    map[string]json.RawMessage `json:"attributes"`
+			Relationships map[string]struct {
+				Data struct {
+					Type string `json:"type"`
+					ID   string `json:"id"`
+				} `json:"data"`
+			} `json:"relationships"`
+			Links map[string]*halLink `json:"links"`
+		} `json:"data"`
+		Included []struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+		} `json:"included"`
+		Links map[string]*halLink `json:"links"`
+	}
+	rr = get("/manufacturers/0005/vehicles?fields=tsn,power&embed=powerSource", MediaTypeJSONAPI, &document)
+	AssertOkStatusCode(t, rr.Code)
+	if len(document.Data) == 0 || document.Links["self"] == nil {
+		t.Fatalf("JSON:API document is bad, got:'%v'", rr.Body.String())
+	}
+	r := document.Data[0]
+	if r.Type != "vehicles" || r.ID == "" || r.Attributes["tsn"] == nil || r.Attributes["links"] != nil || r.Links["canonical"] == nil {
+		t.Fatalf("JSON:API resource is bad, got:'%v'", rr.Body.String())
+	}
+	related := r.Relationships["powerSource"].Data
+	if related.Type != "powerSources" || r.Attributes["powerSource"] != nil {
+		t.Fatalf("JSON:API relationship is bad, got:'%v'", rr.Body.String())
+	}
+	included := false
+	for _, i := range document.Included {
+		included = included || i.Type == related.Type && i.ID == related.ID
+	}
+	if !included {
+		t.Fatalf("JSON:API power source is not included, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get JSON:API error")
+	var errors struct {
+		Errors []struct {
+			Status string `json:"status"`
+		} `json:"errors"`
+	}
+	rr = get("/manufacturers/000x", MediaTypeJSONAPI, &errors)
+	if rr.Code != http.StatusNotFound || len(errors.Errors) != 1 || errors.Errors[0].Status != "404" {
+		t.Fatalf("JSON:API error is bad, got:'%v'", rr.Body.String())
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLanguage is the language of the KBA data set.
+	DefaultLanguage = "de"
+	// languageParam is the query parameter overriding Accept-Language.
+	languageParam = "lang"
+)
+
+// SupportedLanguages are the languages translations are available for.
+var SupportedLanguages = []string{DefaultLanguage, "en"}
+
+func isSupportedLanguage(language string) bool {
+	for _, l := range SupportedLanguages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryLanguage returns the primary subtag of the language tag, e.g. 'en'
+// for 'en-GB'.
+func primaryLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+type weightedLanguage struct {
+	tag    string
+	weight float64
+}
+
+// parseAcceptLanguage parses the Accept-Language header into its language
+// ranges ordered by descending quality.
+func parseAcceptLanguage(header string) []string {
+	var languages []weightedLanguage
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if weight > 0 {
+			languages = append(languages, weightedLanguage{tag, weight})
+		}
+	}
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].weight > languages[j].weight
+	})
+	tags := make([]string, len(languages))
+	for i, l := range languages {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// NegotiateLanguage selects the response language by the lang query parameter
+// or the Accept-Language header, falling back to DefaultLanguage.
+func NegotiateLanguage(r *http.Request) string {
+	if lang := primaryLanguage(r.URL.Query().Get(languageParam)); isSupportedLanguage(lang) {
+		return lang
+	}
+	for _, tag := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if tag == "*" {
+			return DefaultLanguage
+		}
+		if lang := primaryLanguage(tag); isSupportedLanguage(lang) {
+			return lang
+		}
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+
+	for _, test := range []struct {
+		url            string
+		acceptLanguage string
+		want           string
+	}{
+		{"/powerSources", "", "de"},
+		{"/powerSources", "en-GB,en;q=0.9,de;q=0.8", "en"},
+		{"/powerSources", "fr-FR,fr;q=0.9,en;q=0.5,de;q=0.7", "de"},
+		{"/powerSources", "fr, en;q=0.4", "en"},
+		{"/powerSources", "fr", "de"},
+		{"/powerSources", "en;q=0", "de"},
+		{"/powerSources", "*", "de"},
+		{"/powerSources?lang=en", "de", "en"},
+		{"/powerSources?lang=EN-us", "", "en"},
+		{"/powerSources?lang=fr", "en", "en"},
+	} {
+		req, err := http.NewRequest("GET", test.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept-Language", test.acceptLanguage)
+
+		if got := NegotiateLanguage(req); got != test.want {
+			t.Fatalf("language for '%s' and '%s' is bad, got:'%v', want:'%v'",
+				test.url, test.acceptLanguage, got, test.want)
+		}
+	}
+}
+
+func TestServerContentLanguage(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/powerSources/{id}", service.GetPowerSource)
+	server.Get("/powerSources/{id}/vehicles", service.GetPowerSourceVehicles)
+	server.Get("/powerSources/{id}/manufacturers", service.GetPowerSourceManufacturers)
+
+	for _, test := range []struct {
+		path     string
+		language string
+	}{
+		{"/powerSources/1", "en"},
+		{"/manufacturers/0005", ""},
+		{"/manufacturers/000x", ""},
+	} {
+		t.Logf("get %s in english", test.path)
+		req, err := http.NewRequest("GET", test.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		req.Header.Set("Accept-Language", "en")
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		if got := rr.Header().Get("Content-Language"); got != test.language {
+			t.Fatalf("content language is bad, got:'%v', want:'%v'", got, test.language)
+		}
+		varies := strings.Contains(strings.Join(rr.Header().Values("Vary"), ","), "Accept-Language")
+		if varies != (test.language != "") {
+			t.Fatalf("vary is bad, got:'%v'", rr.Header().Values("Vary"))
+		}
+	}
+}
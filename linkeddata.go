@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Media types of the linked data representations.
+const (
+	MediaTypeJSONLD   = "application/ld+json"
+	MediaTypeTurtle   = "text/turtle"
+	MediaTypeNTriples = "application/n-triples"
+)
+
+// Vocabularies of the linked data. The schema.org vocabulary is the one its
+// JSON-LD context expands terms into.
+const (
+	schemaContext    = "https://schema.org"
+	schemaVocabulary = "http://schema.org/"
+	rdfType          = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	xsdVocabulary    = "http://www.w3.org/2001/XMLSchema#"
+)
+
+// UN/CEFACT unit codes of quantitative values.
+const (
+	unitKilowatt        = "KWT"
+	unitCubicCentimetre = "CMQ"
+	unitKilogram        = "KGM"
+)
+
+// Node is a JSON-LD node object in the schema.org vocabulary. Its values are
+// strings, ints, Dates, Nodes or slices of them.
+type Node map[string]interface{}
+
+// described is a resource described by a node.
+type described interface {
+	describe() Node
+}
+
+var describedType = reflect.TypeOf((*described)(nil)).Elem()
+
+// isLinkedDataContent reports whether the content is a described resource or
+// a collection of them.
+func isLinkedDataContent(content interface{}) bool {
+	if _, ok := content.(described); ok {
+		return true
+	}
+	t := reflect.TypeOf(content)
+	return t != nil && t.Kind() == reflect.Slice && t.Elem().Implements(describedType)
+}
+
+// encodeJSONLD encodes the content as JSON-LD, a collection as a graph.
+func encodeJSONLD(content interface{}) ([]byte, error) {
+	if r, ok := content.(described); ok {
+		node := r.describe()
+		node["@context"] = schemaContext
+		return encodeJSON(node)
+	}
+	v := reflect.ValueOf(content)
+	graph := make([]Node, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		graph = append(graph, v.Index(i).Interface().(described).describe())
+	}
+	return encodeJSON(Node{"@context": schemaContext, "@graph": graph})
+}
+
+// linkHref returns the URL of the first link of the relations, in order, or
+// an empty string.
+func linkHref(links []*Link, relations ...string) string {
+	for _, relation := range relations {
+		for _, link := range links {
+			if link.Relation == relation && link.URL != nil {
+				return link.URL.String()
+			}
+		}
+	}
+	return ""
+}
+
+func quantitativeValue(value int, unitCode string) Node {
+	return Node{"@type": "QuantitativeValue", "value": value, "unitCode": unitCode}
+}
+
+func propertyValue(propertyID, value string) Node {
+	return Node{"@type": "PropertyValue", "propertyID": propertyID, "value": value}
+}
+
+// describe describes the manufacturer as an Organization.
+func (m *Manufacturer) describe() Node {
+	node := Node{"@type": "Organization"}
+	if id := linkHref(m.Links, "self", "canonical"); id != "" {
+		node["@id"] = id
+	}
+	if m.ID != "" {
+		node["identifier"] = propertyValue("HSN", m.ID)
+	}
+	if m.Name != "" {
+		node["name"] = m.Name
+	}
+	var alternateNames []interface{}
+	for _, name := range m.Names {
+		if name.Name != m.Name && !containsValue(alternateNames, name.Name) {
+			alternateNames = append(alternateNames, name.Name)
+		}
+	}
+	if len(alternateNames) > 0 {
+		node["alternateName"] = alternateNames
+	}
+	return node
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// passengerCarCategories are the EU vehicle categories and the former
+// national vehicle class of passenger cars.
+var passengerCarCategories = []string{"M1", "M1G", "01"}
+
+// describe describes the vehicle as a Car if it is a passenger car and as a
+// Vehicle otherwise. The maximum mass is the permitted total weight of the
+// loaded vehicle, i.e. weightTotal rather than the weight of the empty
+// vehicle.
+func (v *Vehicle) describe() Node {
+	node := Node{"@type": "Vehicle"}
+	if containsString(passengerCarCategories, v.Category) {
+		node["@type"] = "Car"
+	}
+	if id := linkHref(v.Links, "self", "canonical"); id != "" {
+		node["@id"] = id
+	}
+	var identifiers []interface{}
+	if v.ManufacturerID != "" {
+		identifiers = append(identifiers, propertyValue("HSN", v.ManufacturerID))
+	}
+	if v.TSN != "" {
+		identifiers = append(identifiers, propertyValue("TSN", v.TSN))
+	}
+	if len(identifiers) > 0 {
+		node["identifier"] = identifiers
+	}
+	if v.CommercialName != "" {
+		node["name"] = v.CommercialName
+	}
+	if v.TradeName != "" {
+		node["brand"] = Node{"@type": "Brand", "name": v.TradeName}
+	}
+
+	manufacturer := Node{"@type": "Organization"}
+	if v.Manufacturer != nil {
+		manufacturer = v.Manufacturer.describe()
+	}
+	if _, ok := manufacturer["@id"]; !ok {
+		if id := linkHref(v.Links, "manufacturer"); id != "" {
+			manufacturer["@id"] = id
+		}
+	}
+	if _, ok := manufacturer["name"]; !ok && v.ManufacturerName != "" {
+		manufacturer["name"] = v.ManufacturerName
+	}
+	if len(manufacturer) > 1 {
+		node["manufacturer"] = manufacturer
+	}
+
+	if !v.AllotmentDate.IsZero() {
+		node["vehicleModelDate"] = v.AllotmentDate
+	}
+	if v.Bodywork != "" {
+		node["bodyType"] = v.Bodywork
+	}
+
+	engine := Node{"@type": "EngineSpecification"}
+	if v.Power > 0 {
+		engine["enginePower"] = quantitativeValue(v.Power, unitKilowatt)
+	}
+	if v.EngineCapacity != nil {
+		engine["engineDisplacement"] = quantitativeValue(*v.EngineCapacity, unitCubicCentimetre)
+	}
+	if v.PowerSource != nil {
+		fuelType := v.PowerSource.Description
+		if fuelType == "" {
+			fuelType = v.PowerSource.ShortName
+		}
+		if fuelType != "" {
+			engine["fuelType"] = fuelType
+			node["fuelType"] = fuelType
+		}
+	}
+	if len(engine) > 1 {
+		node["vehicleEngine"] = engine
+	}
+
+	if v.Seats != nil {
+		node["seatingCapacity"] = *v.Seats
+	}
+	if v.Axles != nil {
+		node["numberOfAxles"] = *v.Axles
+	}
+	if v.MaximumMass != nil {
+		node["weightTotal"] = quantitativeValue(*v.MaximumMass, unitKilogram)
+	}
+	return node
+}
+
+// Dataset is a set of RDF triples, represented as Turtle or N-Triples.
+type Dataset struct {
+	triples    []triple
+	seen       map[triple]bool
+	blankNodes int
+}
+
+// triple is a RDF triple of terms in N-Triples syntax.
+type triple struct{ subject, predicate, object string }
+
+// NewDataset creates a new empty Dataset.
+func NewDataset() *Dataset {
+	return &Dataset{seen: map[triple]bool{}}
+}
+
+// Add adds the triples of the node and its nested nodes. Nodes without an
+// '@id' become blank nodes.
+func (d *Dataset) Add(node Node) {
+	d.add(node)
+}
+
+// add adds the triples of the node after those of its nested nodes, so that
+// the triples of a subject are adjacent, and returns its subject term.
+func (d *Dataset) add(node Node) string {
+	var subject string
+	if id, ok := node["@id"].(string); ok {
+		subject = iriTerm(id)
+	} else {
+		d.blankNodes++
+		subject = fmt.Sprintf("_:b%d", d.blankNodes)
+	}
+
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var triples []triple
+	for _, key := range keys {
+		switch key {
+		case "@id", "@context":
+		case "@type":
+			triples = append(triples, triple{subject, iriTerm(rdfType), iriTerm(schemaVocabulary + node[key].(string))})
+		default:
+			for _, object := range d.objects(node[key]) {
+				triples = append(triples, triple{subject, iriTerm(schemaVocabulary + key), object})
+			}
+		}
+	}
+	for _, t := range triples {
+		if strings.HasPrefix(subject, "_:") {
+			// blank nodes are new, so are their triples
+			d.triples = append(d.triples, t)
+		} else if !d.seen[t] {
+			d.seen[t] = true
+			d.triples = append(d.triples, t)
+		}
+	}
+	return subject
+}
+
+// objects returns the object terms of the value.
+func (d *Dataset) objects(value interface{}) []string {
+	switch value := value.(type) {
+	case Node:
+		return []string{d.add(value)}
+	case []Node:
+		objects := []string{}
+		for _, element := range value {
+			objects = append(objects, d.add(element))
+		}
+		return objects
+	case []interface{}:
+		objects := []string{}
+		for _, element := range value {
+			objects = append(objects, d.objects(element)...)
+		}
+		return objects
+	case int:
+		return []string{literalTerm(strconv.Itoa(value)) + "^^" + iriTerm(xsdVocabulary+"integer")}
+	case Date:
+		return []string{literalTerm(value.String()) + "^^" + iriTerm(xsdVocabulary+"date")}
+	}
+	return []string{literalTerm(fmt.Sprint(value))}
+}
+
+func iriTerm(iri string) string {
+	return "<" + iri + ">"
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+
+func literalTerm(value string) string {
+	return `"` + literalEscaper.Replace(value) + `"`
+}
+
+// MediaTypes returns the media types the dataset can be represented in, in
+// order of preference.
+func (d *Dataset) MediaTypes() []string {
+	return []string{MediaTypeTurtle, MediaTypeNTriples}
+}
+
+// Represent encodes the dataset in the media type.
+func (d *Dataset) Represent(mediaType string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch mediaType {
+	case MediaTypeNTriples:
+		for _, t := range d.triples {
+			fmt.Fprintf(&buf, "%s %s %s .\n", t.subject, t.predicate, t.object)
+		}
+	case MediaTypeTurtle:
+		fmt.Fprintf(&buf, "@prefix schema: %s .\n", iriTerm(schemaVocabulary))
+		fmt.Fprintf(&buf, "@prefix xsd: %s .\n", iriTerm(xsdVocabulary))
+		for i, t := range d.triples {
+			if i > 0 && t.subject == d.triples[i-1].subject {
+				fmt.Fprintf(&buf, " ;\n    %s %s", turtleTerm(t.predicate), turtleTerm(t.object))
+				continue
+			}
+			if i > 0 {
+				buf.WriteString(" .")
+			}
+			fmt.Fprintf(&buf, "\n%s %s %s", turtleTerm(t.subject), turtleTerm(t.predicate), turtleTerm(t.object))
+		}
+		if len(d.triples) > 0 {
+			buf.WriteString(" .\n")
+		}
+	default:
+		return nil, fmt.Errorf("media type is not supported: '%s'", mediaType)
+	}
+	return buf.Bytes(), nil
+}
+
+// turtleTerm abbreviates the N-Triples term by the prefixes of the
+// vocabularies.
+func turtleTerm(term string) string {
+	if term == iriTerm(rdfType) {
+		return "a"
+	}
+	for prefix, vocabulary := range map[string]string{"schema:": schemaVocabulary, "xsd:": xsdVocabulary} {
+		start := strings.Index(term, "<"+vocabulary)
+		if start < 0 || start > 0 && term[start-1] != '^' {
+			continue
+		}
+		local := term[start+1+len(vocabulary) : len(term)-1]
+		if isLocalName(local) {
+			return term[:start] + prefix + local
+		}
+	}
+	return term
+}
+
+func isLocalName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDatasetRepresent(t *testing.T) {
+
+	dataset := NewDataset()
+	dataset.Add(Node{
+		"@id":              "http://processing.envirocar.org/manufacturers/0005/vehicles/155",
+		"@type":            "Car",
+		"name":             `645 "CI"`,
+		"seatingCapacity":  4,
+		"vehicleModelDate": NewDate(2003, time.July, 1),
+		"vehicleEngine":    Node{"@type": "EngineSpecification", "fuelType": "Benzin"},
+	})
+
+	t.Log("represent dataset as N-Triples")
+	bytes, err := dataset.Represent(MediaTypeNTriples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<http://processing.envirocar.org/manufacturers/0005/vehicles/155> <http://schema.org/name> "645 \"CI\"" .`
+	if !strings.Contains(string(bytes), want+"\n") {
+		t.Fatalf("N-Triples are bad, got:'%v', want:'%v'", string(bytes), want)
+	}
+	if lines := strings.Count(string(bytes), "\n"); lines != 7 {
+		t.Fatalf("number of triples is bad, got:'%v', want:'%v'", lines, 7)
+	}
+	if !strings.HasPrefix(string(bytes), "_:b1 ") {
+		t.Fatalf("blank node is not first, got:'%v'", string(bytes))
+	}
+
+	t.Log("represent dataset as Turtle")
+	bytes, err = dataset.Represent(MediaTypeTurtle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"@prefix schema: <http://schema.org/> .",
+		"<http://processing.envirocar.org/manufacturers/0005/vehicles/155> a schema:Car ;",
+		`    schema:seatingCapacity "4"^^xsd:integer ;`,
+		`    schema:vehicleModelDate "2003-07-01"^^xsd:date .`,
+	} {
+		if !strings.Contains(string(bytes), want) {
+			t.Fatalf("Turtle is bad, got:'%v', want:'%v'", string(bytes), want)
+		}
+	}
+}
+
+func TestServerLinkedData(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}/alternatives", service.GetAlternatives)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+	server.Get("/powerSources/{id}", service.GetPowerSource)
+	server.Get("/dump", service.GetDump)
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		req.Header.Set("Accept", accept)
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		AssertOkStatusCode(t, rr.Code)
+		if got := rr.Header().Get("Content-Type"); got != accept {
+			t.Fatalf("content type is bad, got:'%v', want:'%v'", got, accept)
+		}
+		return rr
+	}
+
+	t.Log("get JSON-LD vehicle")
+	var vehicle struct {
+		Context       string `json:"@context"`
+		ID            string `json:"@id"`
+		Type          string `json:"@type"`
+		FuelType      string `json:"fuelType"`
+		VehicleEngine struct {
+			Type        string `json:"@type"`
+			EnginePower struct {
+				Value    int    `json:"value"`
+				UnitCode string `json:"unitCode"`
+			} `json:"enginePower"`
+		} `json:"vehicleEngine"`
+		SeatingCapacity int `json:"seatingCapacity"`
+		WeightTotal     struct {
+			UnitCode string `json:"unitCode"`
+		} `json:"weightTotal"`
+		Manufacturer struct {
+			ID   string `json:"@id"`
+			Type string `json:"@type"`
+		} `json:"manufacturer"`
+	}
+	rr := get("/manufacturers/0005/vehicles/155", MediaTypeJSONLD)
+	if err := json.Unmarshal(rr.Body.Bytes(), &vehicle); err != nil {
+		t.Fatal(err)
+	}
+	if vehicle.Context != schemaContext || vehicle.ID != "http://processing.envirocar.org/manufacturers/0005/vehicles/155" || vehicle.Type != "Car" {
+		t.Fatalf("JSON-LD vehicle is bad, got:'%v'", rr.Body.String())
+	}
+	if vehicle.FuelType == "" || vehicle.VehicleEngine.Type != "EngineSpecification" || vehicle.VehicleEngine.EnginePower.Value != 245 ||
+		vehicle.VehicleEngine.EnginePower.UnitCode != unitKilowatt || vehicle.SeatingCapacity == 0 || vehicle.WeightTotal.UnitCode != unitKilogram {
+		t.Fatalf("JSON-LD vehicle properties are bad, got:'%v'", rr.Body.String())
+	}
+	if vehicle.Manufacturer.Type != "Organization" || vehicle.Manufacturer.ID != "http://processing.envirocar.org/manufacturers/0005" {
+		t.Fatalf("JSON-LD manufacturer is bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get JSON-LD vehicles")
+	var graph struct {
+		Graph []map[string]interface{} `json:"@graph"`
+	}
+	rr = get("/manufacturers/0005/vehicles", MediaTypeJSONLD)
+	if err := json.Unmarshal(rr.Body.Bytes(), &graph); err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Graph) == 0 || graph.Graph[0]["@id"] == nil {
+		t.Fatalf("JSON-LD graph is bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get JSON-LD manufacturer")
+	rr = get("/manufacturers/0005", MediaTypeJSONLD)
+	if !strings.Contains(rr.Body.String(), `"@type":"Organization"`) {
+		t.Fatalf("JSON-LD manufacturer is bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get dump")
+	rr = get("/dump", MediaTypeNTriples)
+	want := `<http://processing.envirocar.org/manufacturers/0005/vehicles/155> <http://schema.org/manufacturer> <http://processing.envirocar.org/manufacturers/0005> .`
+	if !strings.Contains(rr.Body.String(), want) {
+		t.Fatalf("dump is bad, want:'%v'", want)
+	}
+}
@@ -1,39 +1,168 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"flag"
 	"fmt"
 	"log"
 	"os"
-	"strconv"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/go-pg/pg/v9"
 	_ "github.com/go-pg/pg/v9/orm"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "serve" {
+		args = args[1:]
+	} else if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		os.Exit(runCLI(args[0], args[1:], os.Stdout, os.Stderr))
+	}
+
+	config, err := LoadConfig(flag.NewFlagSet("vehicles", flag.ExitOnError), args, os.LookupEnv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	serve(config)
+}
+
+// Stores selectable by the database configuration.
+const (
+	StorePostgres = "postgres"
+	StoreMemory   = "memory"
+)
+
+// newRepository creates a new Repository: either connecting to the database
+// or, for the memory store, loading the dataset embedded or from the data
+// directory.
+func newRepository(config *DatabaseConfig) (Repository, error) {
+	switch config.Store {
+	case StorePostgres:
+		return NewPostgresRepository(&pg.Options{
+			User:         config.User,
+			Password:     config.Password,
+			Database:     config.Name,
+			Addr:         config.Addr,
+			PoolSize:     config.PoolSize,
+			MinIdleConns: config.MinIdleConns,
+			DialTimeout:  config.DialTimeout,
+			ReadTimeout:  config.ReadTimeout,
+			WriteTimeout: config.WriteTimeout,
+			PoolTimeout:  config.PoolTimeout,
+			IdleTimeout:  config.IdleTimeout,
+		}), nil
+	case StoreMemory:
+		data, source := EmbeddedData(), "embedded dataset"
+		if config.DataDir != "" {
+			data, source = os.DirFS(config.DataDir), config.DataDir
+		}
+		start := time.Now()
+		repository, err := NewMemoryRepository(data)
+		if err != nil {
+			return nil, fmt.Errorf("could not load %s: %v", source, err)
+		}
+		runtime.GC()
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		log.Printf("Loaded %s in %v, heap in use: %.1f MiB\n",
+			source, time.Since(start).Round(time.Millisecond), float64(stats.HeapInuse)/(1<<20))
+		return repository, nil
+	default:
+		return nil, fmt.Errorf("unknown store: '%s'", config.Store)
+	}
+}
+
+// serve runs the HTTP and gRPC servers.
+func serve(config *Config) {
+
+	logger := config.Log.NewLogger()
+
+	if config.Tracing.Endpoint != "" {
+		provider, err := NewTracerProvider(&config.Tracing)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer provider.Shutdown(context.Background())
+	}
 
-	repository := NewRepository(&pg.Options{
-		User:     getenv("DB_USER", "postgres"),
-		Password: getenv("DB_PASS", "postgres"),
-		Database: getenv("DB_NAME", "vehicles"),
-		Addr:     getenv("DB_ADDR", "localhost:5432"),
-	})
+	repository, err := newRepository(&config.Database)
+	if err != nil {
+		log.Fatal(err)
+	}
 	defer repository.Close()
 
+	if r, ok := repository.(*PostgresRepository); ok && config.Database.Migrate {
+		migrator, err := r.Migrator()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := migrator.Migrate(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	s := NewService(repository)
 	defer s.Close()
 
-	server := NewServer()
+	gql, err := NewGraphQL(repository)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tlsConfig *tls.Config
+	var grpcOptions []grpc.ServerOption
+	if config.TLS.CertFile != "" {
+		tlsConfig, err = NewTLSConfig(&config.TLS, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+		grpcOptions = append(grpcOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := NewServer(
+		WithLogger(logger),
+		WithTimeouts(config.Server.ReadTimeout, config.Server.WriteTimeout, config.Server.IdleTimeout),
+		WithTLS(tlsConfig),
+		WithCacheMaxAge(config.Cache.MaxAge),
+		WithCORS(config.CORS),
+		WithCompression(config.Compression.MinSize, config.Compression.Encodings...),
+	)
 
 	server.Get("/", s.GetRoot)
 	server.Get("/manufacturers", s.GetManufacturers)
 	server.Get("/manufacturers/{hsn}", s.GetManufacturer)
 	server.Get("/manufacturers/{hsn}/vehicles", s.GetVehicles)
 	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", s.GetVehicle)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}/alternatives", s.GetAlternatives)
+	server.Get("/manufacturers/{hsn}/models", s.GetModels)
+	server.Get("/manufacturers/{hsn}/models/{model}", s.GetModel)
 	server.Get("/powerSources", s.GetPowerSources)
 	server.Get("/powerSources/{id}", s.GetPowerSource)
+	server.Get("/powerSources/{id}/vehicles", s.GetPowerSourceVehicles)
+	server.Get("/powerSources/{id}/manufacturers", s.GetPowerSourceManufacturers)
+	server.Get("/vehicles", s.SearchVehicles)
+	server.Get("/vins/{vin}", s.GetVIN)
+	server.Get("/dump", s.GetDump)
+	server.Get("/graphql", gql.Query)
+	server.Post("/graphql", gql.Query)
+
+	grpcServer := NewGRPCServer(repository, logger, grpcOptions...)
+	go func() {
+		log.Fatal(grpcServer.Start(config.Server.GRPCAddress))
+	}()
+	defer grpcServer.Stop()
+
+	go func() {
+		log.Fatal(startHealthServer(config.Server.HealthAddress))
+	}()
 
-	log.Fatal(server.Start(fmt.Sprintf(":%d", getPort())))
+	log.Fatal(server.Start(config.Server.Address))
 }
 
 func getenv(name, defaultValue string) string {
@@ -42,13 +171,3 @@ func getenv(name, defaultValue string) string {
 	}
 	return defaultValue
 }
-
-func getPort() int {
-	portStr := os.Getenv("PORT")
-	if portStr != "" {
-		if port, err := strconv.Atoi(portStr); err == nil {
-			return port
-		}
-	}
-	return 8080
-}
@@ -7,11 +7,28 @@ import (
 // Manufacturer is a vehicle manufacturer.
 type Manufacturer struct {
 	Linked `pg:"-"`
-	ID     string `pg:",pk" json:"hsn,omitempty"`
-	Name   string `json:"name,omitempty"`
+	ID     string              `pg:",pk" json:"hsn,omitempty"`
+	Name   string              `json:"name,omitempty"`
+	Names  []*ManufacturerName `pg:"-" json:"names,omitempty"`
 }
 
 func (m *Manufacturer) String() string {
 	bytes, _ := json.Marshal(m)
 	return string(bytes)
 }
+
+func (m *Manufacturer) identity() (string, string) {
+	return "manufacturers", m.ID
+}
+
+// ManufacturerName is a name a manufacturer was registered under for a
+// period. ValidFrom and ValidTo are the first and the last allotment date of
+// the period, in which vehicles under the name were allotted at every
+// allotment date of the manufacturer. A manufacturer may be registered under
+// several names at once and under a name for several periods.
+type ManufacturerName struct {
+	ManufacturerID string `pg:",pk" json:"-"`
+	Name           string `pg:",pk" json:"name"`
+	ValidFrom      Date   `pg:",pk" json:"from"`
+	ValidTo        Date   `json:"to"`
+}
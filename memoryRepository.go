@@ -0,0 +1,536 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// embeddedData is the KBA dataset embedded into the binary.
+//
+//go:embed db/*.csv
+var embeddedData embed.FS
+
+// EmbeddedData returns the embedded dataset.
+func EmbeddedData() fs.FS {
+	data, _ := fs.Sub(embeddedData, "db")
+	return data
+}
+
+// MemoryRepository is a read-only vehicle repository indexed in memory.
+type MemoryRepository struct {
+	manufacturers          []*Manufacturer
+	manufacturersByID      map[string]*Manufacturer
+	vehicles               []*Vehicle
+	vehiclesByManufacturer map[string][]*Vehicle
+	vehiclesByID           map[string]*Vehicle
+	powerSources           []*PowerSource
+	powerSourcesByID       map[int]*PowerSource
+	translations           map[string]map[int]*PowerSource
+	classifications        map[int]*PowerSourceClassification
+	wmis                   map[string]*WMI
+	wmiManufacturers       map[string][]string
+}
+
+var _ Repository = (*MemoryRepository)(nil)
+
+// NewMemoryRepository creates a new MemoryRepository from the CSV files of
+// the dataset, i.e. 'vehicles.csv' and 'power_sources.csv' and optionally
+// 'power_source_translations.csv', 'power_source_classifications.csv',
+// 'wmis.csv' and 'wmi_manufacturers.csv'.
+func NewMemoryRepository(data fs.FS) (*MemoryRepository, error) {
+	r := &MemoryRepository{
+		manufacturersByID:      map[string]*Manufacturer{},
+		vehiclesByManufacturer: map[string][]*Vehicle{},
+		vehiclesByID:           map[string]*Vehicle{},
+		powerSourcesByID:       map[int]*PowerSource{},
+		translations:           map[string]map[int]*PowerSource{},
+		classifications:        map[int]*PowerSourceClassification{},
+		wmis:                   map[string]*WMI{},
+		wmiManufacturers:       map[string][]string{},
+	}
+	loaders := []struct {
+		name     string
+		optional bool
+		load     func(record []string) error
+	}{
+		{"power_sources.csv", false, r.loadPowerSource},
+		{"power_source_translations.csv", true, r.loadPowerSourceTranslation},
+		{"power_source_classifications.csv", true, r.loadPowerSourceClassification},
+		{"vehicles.csv", false, r.loadVehicle},
+		{"wmis.csv", true, r.loadWMI},
+		{"wmi_manufacturers.csv", true, r.loadWMIManufacturer},
+	}
+	for _, loader := range loaders {
+		err := readCSV(data, loader.name, loader.load)
+		if err != nil && !(loader.optional && errors.Is(err, fs.ErrNotExist)) {
+			return nil, err
+		}
+	}
+	r.index()
+	return r, nil
+}
+
+// readCSV calls load with every record of the CSV file after its header.
+func readCSV(data fs.FS, name string, load func(record []string) error) error {
+	file, err := data.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.ReuseRecord = true
+	if _, err := reader.Read(); err != nil {
+		return fmt.Errorf("could not read header of '%s': %v", name, err)
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not read '%s': %v", name, err)
+		}
+		if err := load(record); err != nil {
+			line, _ := reader.FieldPos(0)
+			return fmt.Errorf("could not load '%s' line %d: %v", name, line, err)
+		}
+	}
+}
+
+// parseCSVInt parses an integer column, an empty column being zero.
+func parseCSVInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// parseCSVNullInt parses a nullable integer column, an empty column being
+// nil.
+func parseCSVNullInt(value string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *MemoryRepository) loadPowerSource(record []string) error {
+	id, err := strconv.Atoi(record[0])
+	if err != nil {
+		return err
+	}
+	ps := &PowerSource{ID: id, ShortName: record[1], Description: record[2]}
+	r.powerSources = append(r.powerSources, ps)
+	r.powerSourcesByID[id] = ps
+	return nil
+}
+
+func (r *MemoryRepository) loadPowerSourceTranslation(record []string) error {
+	id, err := strconv.Atoi(record[0])
+	if err != nil {
+		return err
+	}
+	language := record[1]
+	if r.translations[language] == nil {
+		r.translations[language] = map[int]*PowerSource{}
+	}
+	r.translations[language][id] = &PowerSource{ID: id, ShortName: record[2], Description: record[3]}
+	return nil
+}
+
+func (r *MemoryRepository) loadPowerSourceClassification(record []string) error {
+	c, err := parsePowerSourceClassification(record)
+	if err != nil {
+		return err
+	}
+	if _, ok := r.powerSourcesByID[c.PowerSourceID]; !ok {
+		return fmt.Errorf("unknown power source '%d'", c.PowerSourceID)
+	}
+	r.classifications[c.PowerSourceID] = c
+	return nil
+}
+
+func (r *MemoryRepository) loadVehicle(record []string) error {
+	allotmentDate, err := parseDate(germanDateLayout, record[5])
+	if err != nil {
+		return err
+	}
+	v := &Vehicle{
+		ManufacturerID:   record[0],
+		TSN:              record[1],
+		ManufacturerName: record[2],
+		TradeName:        record[3],
+		CommercialName:   record[4],
+		AllotmentDate:    allotmentDate,
+		Category:         record[6],
+		Bodywork:         record[7],
+	}
+	for i, field := range []*int{&v.PowerSourceID, &v.Power} {
+		if *field, err = parseCSVInt(record[8+i]); err != nil {
+			return err
+		}
+	}
+	nullInts := []**int{&v.EngineCapacity, &v.Axles, &v.PoweredAxles, &v.Seats, &v.MaximumMass}
+	for i, field := range nullInts {
+		if *field, err = parseCSVNullInt(record[10+i]); err != nil {
+			return err
+		}
+	}
+	if _, ok := r.powerSourcesByID[v.PowerSourceID]; !ok {
+		return fmt.Errorf("unknown power source '%d'", v.PowerSourceID)
+	}
+	r.vehicles = append(r.vehicles, v)
+	return nil
+}
+
+func (r *MemoryRepository) loadWMI(record []string) error {
+	r.wmis[record[0]] = &WMI{ID: record[0], Name: record[1], Country: record[2]}
+	return nil
+}
+
+func (r *MemoryRepository) loadWMIManufacturer(record []string) error {
+	r.wmiManufacturers[record[0]] = append(r.wmiManufacturers[record[0]], record[1])
+	return nil
+}
+
+// index derives the manufacturers and their names from the vehicles like the
+// database schema does and builds the indexes.
+func (r *MemoryRepository) index() {
+	sort.Slice(r.vehicles, func(i, j int) bool {
+		if r.vehicles[i].ManufacturerID != r.vehicles[j].ManufacturerID {
+			return r.vehicles[i].ManufacturerID < r.vehicles[j].ManufacturerID
+		}
+		return r.vehicles[i].TSN < r.vehicles[j].TSN
+	})
+	sort.Slice(r.powerSources, func(i, j int) bool {
+		return r.powerSources[i].ID < r.powerSources[j].ID
+	})
+
+	for _, v := range r.vehicles {
+		r.vehiclesByID[v.ManufacturerID+"/"+v.TSN] = v
+		r.vehiclesByManufacturer[v.ManufacturerID] = append(r.vehiclesByManufacturer[v.ManufacturerID], v)
+
+		if _, ok := r.manufacturersByID[v.ManufacturerID]; !ok {
+			m := &Manufacturer{ID: v.ManufacturerID}
+			r.manufacturersByID[m.ID] = m
+			r.manufacturers = append(r.manufacturers, m)
+		}
+	}
+
+	for _, m := range r.manufacturers {
+		m.Names = manufacturerNames(m.ID, r.vehiclesByManufacturer[m.ID])
+		m.Name = currentManufacturerName(m.Names)
+	}
+}
+
+// manufacturerNames returns the periods of the names of the manufacturer of
+// the vehicles like the database schema does, ordered by their start and
+// name. A name is used in a period of consecutive allotment dates of the
+// manufacturer, names used at the same dates have overlapping periods.
+func manufacturerNames(manufacturerID string, vehicles []*Vehicle) []*ManufacturerName {
+	used := map[string]map[string]bool{}
+	var dates []Date
+	for _, v := range vehicles {
+		date := v.AllotmentDate.String()
+		if used[date] == nil {
+			used[date] = map[string]bool{}
+			dates = append(dates, v.AllotmentDate)
+		}
+		used[date][v.ManufacturerName] = true
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	var names []*ManufacturerName
+	current := map[string]*ManufacturerName{}
+	for _, date := range dates {
+		next := map[string]*ManufacturerName{}
+		for name := range used[date.String()] {
+			if n, ok := current[name]; ok {
+				n.ValidTo = date
+				next[name] = n
+				continue
+			}
+			n := &ManufacturerName{
+				ManufacturerID: manufacturerID,
+				Name:           name,
+				ValidFrom:      date,
+				ValidTo:        date,
+			}
+			names = append(names, n)
+			next[name] = n
+		}
+		current = next
+	}
+	sort.Slice(names, func(i, j int) bool {
+		switch {
+		case names[i].ValidFrom.Before(names[j].ValidFrom):
+			return true
+		case names[j].ValidFrom.Before(names[i].ValidFrom):
+			return false
+		}
+		return names[i].Name < names[j].Name
+	})
+	return names
+}
+
+// currentManufacturerName returns the current name of the ordered names, the
+// longest used one of the latest date.
+func currentManufacturerName(names []*ManufacturerName) string {
+	var current *ManufacturerName
+	for _, n := range names {
+		if current == nil || n.ValidTo.After(current.ValidTo) {
+			current = n
+		}
+	}
+	return current.Name
+}
+
+// Close closes this repository.
+func (r *MemoryRepository) Close() error {
+	return nil
+}
+
+// WithContext returns this repository, that does not query.
+func (r *MemoryRepository) WithContext(context.Context) Repository {
+	return r
+}
+
+// manufacturer returns a copy of the manufacturer, optionally with its names.
+func (r *MemoryRepository) manufacturer(m *Manufacturer, withNames bool) *Manufacturer {
+	c := &Manufacturer{ID: m.ID, Name: m.Name}
+	if withNames {
+		for _, name := range m.Names {
+			n := *name
+			c.Names = append(c.Names, &n)
+		}
+	}
+	return c
+}
+
+// powerSource returns a copy of the power source in the specified language.
+func (r *MemoryRepository) powerSource(ps *PowerSource, language string) *PowerSource {
+	c := &PowerSource{ID: ps.ID, ShortName: ps.ShortName, Description: ps.Description}
+	if classification, ok := r.classifications[ps.ID]; ok {
+		cc := *classification
+		cc.EnergyCarriers = append([]string{}, classification.EnergyCarriers...)
+		c.Classification = &cc
+	}
+	if t, ok := r.translations[language][ps.ID]; ok && language != DefaultLanguage {
+		if t.ShortName != "" {
+			c.ShortName = t.ShortName
+		}
+		if t.Description != "" {
+			c.Description = t.Description
+		}
+	}
+	return c
+}
+
+// vehicle returns a copy of the vehicle without its relations.
+func (r *MemoryRepository) vehicle(v *Vehicle) *Vehicle {
+	c := *v
+	return &c
+}
+
+// GetManufacturers returns all manufacturers.
+func (r *MemoryRepository) GetManufacturers() ([]*Manufacturer, error) {
+	entities := make([]*Manufacturer, len(r.manufacturers))
+	for i, m := range r.manufacturers {
+		entities[i] = r.manufacturer(m, false)
+	}
+	return entities, nil
+}
+
+// GetManufacturer returns the specified manufacturer.
+func (r *MemoryRepository) GetManufacturer(id string) (*Manufacturer, error) {
+	m, ok := r.manufacturersByID[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return r.manufacturer(m, true), nil
+}
+
+// GetManufacturersByIDs returns the manufacturers with the ids.
+func (r *MemoryRepository) GetManufacturersByIDs(ids []string) ([]*Manufacturer, error) {
+	var entities []*Manufacturer
+	for _, id := range ids {
+		if m, ok := r.manufacturersByID[id]; ok {
+			entities = append(entities, r.manufacturer(m, true))
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].ID < entities[j].ID })
+	return entities, nil
+}
+
+// GetVehicles returns all vehicles of the manufacturer.
+func (r *MemoryRepository) GetVehicles(manufacturer *Manufacturer) ([]*Vehicle, error) {
+	vehicles := []*Vehicle{}
+	for _, v := range r.vehiclesByManufacturer[manufacturer.ID] {
+		vehicles = append(vehicles, &Vehicle{
+			ManufacturerID:   v.ManufacturerID,
+			TSN:              v.TSN,
+			ManufacturerName: v.ManufacturerName,
+			TradeName:        v.TradeName,
+			CommercialName:   v.CommercialName,
+			AllotmentDate:    v.AllotmentDate,
+		})
+	}
+	return vehicles, nil
+}
+
+// GetVehicle tries to get the specified vehicle with its power source in the
+// specified language.
+func (r *MemoryRepository) GetVehicle(manufacturer *Manufacturer, id string, language string) (*Vehicle, error) {
+	v, ok := r.vehiclesByID[manufacturer.ID+"/"+id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	vehicle := r.vehicle(v)
+	vehicle.Manufacturer = r.manufacturer(r.manufacturersByID[v.ManufacturerID], false)
+	vehicle.PowerSource = r.powerSource(r.powerSourcesByID[v.PowerSourceID], language)
+	return vehicle, nil
+}
+
+// GetPowerSources gets all available power sources in the specified language.
+func (r *MemoryRepository) GetPowerSources(language string) ([]*PowerSource, error) {
+	entities := make([]*PowerSource, len(r.powerSources))
+	for i, ps := range r.powerSources {
+		entities[i] = r.powerSource(ps, language)
+	}
+	return entities, nil
+}
+
+// GetPowerSource gets the specified power source in the specified language.
+func (r *MemoryRepository) GetPowerSource(id string, language string) (*PowerSource, error) {
+	nid, err := parsePowerSourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	ps, ok := r.powerSourcesByID[nid]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return r.powerSource(ps, language), nil
+}
+
+// GetWMI returns the specified world manufacturer identifier.
+func (r *MemoryRepository) GetWMI(id string) (*WMI, error) {
+	wmi, ok := r.wmis[id]
+	if !ok {
+		return nil, NewErrNotFoundF("unknown world manufacturer identifier '%v'", id)
+	}
+	c := *wmi
+	return &c, nil
+}
+
+// GetManufacturersByWMI returns the manufacturers registered for the world
+// manufacturer identifier.
+func (r *MemoryRepository) GetManufacturersByWMI(wmi *WMI) ([]*Manufacturer, error) {
+	entities := []*Manufacturer{}
+	for _, id := range r.wmiManufacturers[wmi.ID] {
+		if m, ok := r.manufacturersByID[id]; ok {
+			entities = append(entities, r.manufacturer(m, false))
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].ID < entities[j].ID })
+	return entities, nil
+}
+
+// GetManufacturersByPowerSource returns the manufacturers of vehicles with the
+// power source.
+func (r *MemoryRepository) GetManufacturersByPowerSource(powerSource *PowerSource, limit, offset int) ([]*Manufacturer, error) {
+	entities := []*Manufacturer{}
+	skipped := 0
+	for _, m := range r.manufacturers {
+		if limit > 0 && len(entities) >= limit {
+			break
+		}
+		found := false
+		for _, v := range r.vehiclesByManufacturer[m.ID] {
+			if v.PowerSourceID == powerSource.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		entities = append(entities, r.manufacturer(m, false))
+	}
+	return entities, nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fuelFamily returns the fuel family of the power source, if classified.
+func (r *MemoryRepository) fuelFamily(powerSourceID int) string {
+	if c, ok := r.classifications[powerSourceID]; ok {
+		return c.FuelFamily
+	}
+	return ""
+}
+
+// FindVehicles returns the vehicles matching the filter.
+func (r *MemoryRepository) FindVehicles(filter *VehicleFilter) ([]*Vehicle, error) {
+	candidates := r.vehicles
+	if filter.ManufacturerID != "" {
+		candidates = r.vehiclesByManufacturer[filter.ManufacturerID]
+	}
+	contains := func(s, substr string) bool {
+		return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+	}
+	vehicles := []*Vehicle{}
+	skipped := 0
+	for _, v := range candidates {
+		if filter.Limit > 0 && len(vehicles) >= filter.Limit {
+			break
+		}
+		switch {
+		case filter.Query != "" &&
+			!contains(v.TradeName, filter.Query) &&
+			!contains(v.CommercialName, filter.Query) &&
+			!contains(v.ManufacturerName, filter.Query),
+			filter.PowerSourceID != nil && v.PowerSourceID != *filter.PowerSourceID,
+			len(filter.PowerSourceIDs) > 0 && !containsInt(filter.PowerSourceIDs, v.PowerSourceID),
+			filter.FuelFamily != "" && r.fuelFamily(v.PowerSourceID) != filter.FuelFamily,
+			filter.TradeName != "" && !contains(v.TradeName, filter.TradeName),
+			filter.CommercialName != "" && !contains(v.CommercialName, filter.CommercialName),
+			filter.Category != "" && v.Category != filter.Category,
+			filter.MinPower > 0 && v.Power < filter.MinPower,
+			filter.MaxPower > 0 && v.Power > filter.MaxPower,
+			!filter.AllotmentDateFrom.IsZero() && v.AllotmentDate.Before(filter.AllotmentDateFrom),
+			!filter.AllotmentDateTo.IsZero() && v.AllotmentDate.After(filter.AllotmentDateTo):
+			continue
+		}
+		if skipped < filter.Offset {
+			skipped++
+			continue
+		}
+		vehicles = append(vehicles, r.vehicle(v))
+	}
+	return vehicles, nil
+}
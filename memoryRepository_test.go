@@ -0,0 +1,277 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func NewTestMemoryRepository(t *testing.T) *MemoryRepository {
+	t.Log("create test memory repository")
+	repository, err := NewMemoryRepository(EmbeddedData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repository
+}
+
+func TestMemoryRepositoryGetManufacturer(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("get manufacturer")
+	m, err := r.GetManufacturer("0005")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name != "BMW" {
+		t.Fatalf("name is bad, got:'%v', want:'%v'", m.Name, "BMW")
+	}
+	if len(m.Names) != 1 || m.Names[0].ValidFrom.String() != "1949-11-01" || m.Names[0].ValidTo.String() != "2019-07-15" {
+		t.Fatalf("names are bad, got:'%v'", m)
+	}
+
+	t.Log("get unknown manufacturer")
+	if _, err := r.GetManufacturer("000x"); err != ErrNotFound {
+		t.Fatalf("error is bad, got:'%v', want:'%v'", err, ErrNotFound)
+	}
+}
+
+func TestManufacturerNames(t *testing.T) {
+
+	vehicle := func(name string, year int) *Vehicle {
+		return &Vehicle{ManufacturerName: name, AllotmentDate: NewDate(year, time.January, 1)}
+	}
+	vehicles := []*Vehicle{
+		vehicle("A", 1990), vehicle("A", 1991), vehicle("B", 1992), vehicle("A", 1992),
+		vehicle("B", 1992), vehicle("B", 1993), vehicle("A", 1994), vehicle("A", 1995),
+	}
+
+	t.Log("get names of a renamed manufacturer regaining its name")
+	names := manufacturerNames("0005", vehicles)
+	want := "A 1990-01-01 1992-01-01, B 1992-01-01 1993-01-01, A 1994-01-01 1995-01-01"
+	var got []string
+	for _, name := range names {
+		got = append(got, fmt.Sprintf("%s %s %s", name.Name, name.ValidFrom, name.ValidTo))
+	}
+	if strings.Join(got, ", ") != want {
+		t.Fatalf("names are bad, got:'%v', want:'%v'", strings.Join(got, ", "), want)
+	}
+
+	vehicles = []*Vehicle{
+		vehicle("A", 1990), vehicle("A", 1990), vehicle("B", 1990), vehicle("A", 1991),
+		vehicle("A", 1991), vehicle("B", 1991), vehicle("A", 1992), vehicle("C", 1992),
+	}
+
+	t.Log("get names of a manufacturer registered under two names at the same dates")
+	names = manufacturerNames("0005", vehicles)
+	want = "A 1990-01-01 1992-01-01, B 1990-01-01 1991-01-01, C 1992-01-01 1992-01-01"
+	got = nil
+	for _, name := range names {
+		got = append(got, fmt.Sprintf("%s %s %s", name.Name, name.ValidFrom, name.ValidTo))
+	}
+	if strings.Join(got, ", ") != want {
+		t.Fatalf("names are bad, got:'%v', want:'%v'", strings.Join(got, ", "), want)
+	}
+	if current := currentManufacturerName(names); current != "A" {
+		t.Fatalf("current name is bad, got:'%v', want:'%v'", current, "A")
+	}
+}
+
+func TestMemoryRepositoryGetManufacturerNames(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+	for hsn, name := range map[string]string{"0710": "MERCEDES-BENZ (D)", "1005": "FORD (F)"} {
+		t.Logf("get names of manufacturer %s", hsn)
+		m, err := r.GetManufacturer(hsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hasManufacturerName(m, name) {
+			t.Fatalf("names are bad, got:'%v', want:'%v'", m.Names, name)
+		}
+	}
+}
+
+func TestMemoryRepositoryGetVehicle(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("get vehicle by id and manufacturer")
+	v, err := r.GetVehicle(&Manufacturer{ID: "0005"}, "155", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"hsn":"0005","tsn":"155","manufacturerName":"BMW","commercialName":"645CI","allotmentDate":"2003-07-01","category":"01","bodywork":"0200","power":245,"engineCapacity":4398,"axles":2,"poweredAxles":1,"seats":4,"maximumMass":2070}`
+	if v.String() != want {
+		t.Fatalf("vehicle is bad, got:'%v', want:'%v'", v, want)
+	}
+	if v.PowerSource.ShortName != "Petrol" || v.Manufacturer.Name != "BMW" {
+		t.Fatalf("relations are bad, got:'%v', '%v'", v.PowerSource, v.Manufacturer)
+	}
+
+	t.Log("modify returned vehicle")
+	v.AddLink(&Link{Relation: "self"})
+	if v, _ = r.GetVehicle(&Manufacturer{ID: "0005"}, "155", DefaultLanguage); len(v.Links) != 0 {
+		t.Fatalf("links are bad, got:'%v'", v.Links)
+	}
+}
+
+func TestMemoryRepositoryGetPowerSource(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("get power source")
+	p, err := r.GetPowerSource("1", DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ShortName != "Benzin" {
+		t.Fatalf("short name is bad, got:'%v', want:'%v'", p.ShortName, "Benzin")
+	}
+
+	t.Log("get classified plug-in hybrid power source")
+	p, err = r.GetPowerSource("26", DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := p.Classification; c == nil || c.FuelFamily != FuelFamilyPlugInHybrid ||
+		c.HybridType != HybridTypePlugIn || !c.Electrified || len(c.EnergyCarriers) != 2 {
+		t.Fatalf("classification is bad, got:'%v'", p)
+	}
+
+	t.Log("get power source with bad id")
+	_, err = r.GetPowerSource("1x", DefaultLanguage)
+	if e, ok := err.(Error); !ok || e.Status() != 404 {
+		t.Fatalf("error is bad, got:'%v'", err)
+	}
+}
+
+func TestMemoryRepositoryFindVehicles(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("find vehicles")
+	vehicles, err := r.FindVehicles(&VehicleFilter{Query: "645ci", ManufacturerID: "0005", Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) != 1 || vehicles[0].TSN != "156" {
+		t.Fatalf("vehicles are bad, got:'%v'", vehicles)
+	}
+
+	t.Log("find vehicles by fuel family")
+	vehicles, err = r.FindVehicles(&VehicleFilter{ManufacturerID: "0005", FuelFamily: FuelFamilyElectric})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) == 0 {
+		t.Fatal("no electric vehicles found")
+	}
+	for _, v := range vehicles {
+		if v.PowerSourceID != 4 {
+			t.Fatalf("power source is bad, got:'%v', want:'%v'", v.PowerSourceID, 4)
+		}
+	}
+
+	t.Log("find vehicles by allotment date")
+	from, to := NewDate(2003, time.July, 1), NewDate(2003, time.December, 31)
+	vehicles, err = r.FindVehicles(&VehicleFilter{ManufacturerID: "0005", AllotmentDateFrom: from, AllotmentDateTo: to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) == 0 {
+		t.Fatal("no vehicles found")
+	}
+	for _, v := range vehicles {
+		if v.AllotmentDate.Before(from) || v.AllotmentDate.After(to) {
+			t.Fatalf("allotment date is bad, got:'%v'", v.AllotmentDate)
+		}
+	}
+}
+
+func TestMemoryRepositoryGetManufacturersByWMI(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("get manufacturers by wmi")
+	wmi, err := r.GetWMI("WBA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manufacturers, err := r.GetManufacturersByWMI(wmi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manufacturers) == 0 || manufacturers[0].ID != "0005" {
+		t.Fatalf("manufacturers are bad, got:'%v'", manufacturers)
+	}
+}
+
+func TestMemoryRepositoryGetManufacturersByPowerSource(t *testing.T) {
+
+	r := NewTestMemoryRepository(t)
+
+	t.Log("get manufacturers by power source")
+	manufacturers, err := r.GetManufacturersByPowerSource(&PowerSource{ID: 4}, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manufacturers) < 2 {
+		t.Fatalf("manufacturers are bad, got:'%v'", manufacturers)
+	}
+
+	t.Log("get page of manufacturers by power source")
+	page, err := r.GetManufacturersByPowerSource(&PowerSource{ID: 4}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || page[0].ID != manufacturers[1].ID {
+		t.Fatalf("page is bad, got:'%v', want:'%v'", page, manufacturers[1])
+	}
+}
+
+func TestMemoryRepositoryLoad(t *testing.T) {
+
+	t.Log("load dataset without optional files")
+	data := fstest.MapFS{
+		"power_sources.csv": {Data: []byte("Code,Kurzbezeichnung,Beschreibung\n1,Benzin,Benzin\n")},
+		"vehicles.csv": {Data: []byte("HSN,TSN,Hersteller,Marke,Handelsname,Datum,Klasse,Aufbau,Kraftstoff,Leistung,Hubraum,Achsen,Antriebsachsen,Sitze,Masse\n" +
+			"0005,155,BMW,,645CI,01.07.2003,01,0200,01,245,,2,1,4,2070\n")},
+	}
+	r, err := NewMemoryRepository(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetWMI("WBA"); err == nil {
+		t.Fatal("error is nil")
+	}
+
+	t.Log("get vehicle with unknown engine capacity")
+	v, err := r.GetVehicle(&Manufacturer{ID: "0005"}, "155", DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.EngineCapacity != nil || v.Axles == nil || *v.Axles != 2 {
+		t.Fatalf("nullable attributes are bad, got:'%v'", v)
+	}
+	if !strings.Contains(v.String(), `"engineCapacity":null`) {
+		t.Fatalf("unknown engine capacity is not null, got:'%v'", v)
+	}
+
+	t.Log("load dataset with unknown power source")
+	data["power_sources.csv"] = &fstest.MapFile{Data: []byte("Code,Kurzbezeichnung,Beschreibung\n")}
+	if _, err := NewMemoryRepository(data); err == nil {
+		t.Fatal("error is nil")
+	}
+
+	t.Log("load dataset without vehicles")
+	delete(data, "vehicles.csv")
+	if _, err := NewMemoryRepository(data); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("error is bad, got:'%v', want:'%v'", err, fs.ErrNotExist)
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
+)
+
+// migrationLockID is the key of the advisory lock held while migrating, so
+// that concurrent instances apply every migration only once.
+const migrationLockID = 7269636172
+
+// migrationFiles are the SQL migrations named '<version>_<name>.sql'.
+//
+//go:embed db/migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration is a versioned, forward-only change of the database.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *pg.Tx) error
+}
+
+// MigrationStatus is the status of a migration.
+type MigrationStatus struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// codeMigrations are the migrations that are not plain SQL.
+var codeMigrations = []*Migration{
+	{Version: 2, Name: "load_dataset", Up: loadDataset},
+	{Version: 5, Name: "load_power_source_classifications", Up: loadPowerSourceClassifications},
+}
+
+// Migrator applies the migrations to the database.
+type Migrator struct {
+	db         *pg.DB
+	migrations []*Migration
+}
+
+// NewMigrator creates a new Migrator of the embedded SQL migrations and the
+// code migrations.
+func NewMigrator(db *pg.DB) (*Migrator, error) {
+	migrations, err := sqlMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	migrations = append(migrations, codeMigrations...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// sqlMigrations reads the SQL migrations.
+func sqlMigrations(files fs.FS) ([]*Migration, error) {
+	names, err := fs.Glob(files, "db/migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var migrations []*Migration
+	for _, name := range names {
+		base := strings.TrimSuffix(path.Base(name), ".sql")
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("bad migration file name: '%s'", name)
+		}
+		query, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, &Migration{
+			Version: version,
+			Name:    parts[1],
+			Up: func(tx *pg.Tx) error {
+				_, err := tx.Exec(string(query))
+				return err
+			},
+		})
+	}
+	return migrations, nil
+}
+
+// Migrate applies the pending migrations, each in its own transaction, and
+// returns them.
+func (m *Migrator) Migrate() ([]*Migration, error) {
+	conn := m.db.Conn()
+	defer conn.Close()
+
+	if _, err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID); err != nil {
+		return nil, fmt.Errorf("could not lock migrations: %v", err)
+	}
+	defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+
+	if err := createMigrationsTable(conn); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated []*Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		log.Printf("Applying migration %04d %s\n", migration.Version, migration.Name)
+		err := conn.RunInTransaction(func(tx *pg.Tx) error {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+			_, err := tx.Exec("INSERT INTO schema_migrations (version, name) VALUES (?, ?)",
+				migration.Version, migration.Name)
+			return err
+		})
+		if err != nil {
+			return migrated, fmt.Errorf("could not apply migration %04d %s: %v", migration.Version, migration.Name, err)
+		}
+		migrated = append(migrated, migration)
+	}
+	return migrated, nil
+}
+
+// Status returns the status of all known and applied migrations. It only
+// reads the database, so that it works for read-only roles.
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	applied, err := appliedMigrations(m.db)
+	if err != nil {
+		return nil, err
+	}
+	var status []*MigrationStatus
+	for _, migration := range m.migrations {
+		s := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if a, ok := applied[migration.Version]; ok {
+			s.AppliedAt = a.AppliedAt
+			delete(applied, migration.Version)
+		}
+		status = append(status, s)
+	}
+	// migrations applied by a newer version of the service
+	for _, a := range applied {
+		status = append(status, a)
+	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Version < status[j].Version })
+	return status, nil
+}
+
+func createMigrationsTable(db orm.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version int PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("could not create migrations table: %v", err)
+	}
+	return nil
+}
+
+// pgUndefinedTable is the Postgres error code of a missing table.
+const pgUndefinedTable = "42P01"
+
+// appliedMigrations returns the applied migrations by version. None are
+// applied if the migrations table does not exist yet.
+func appliedMigrations(db orm.DB) (map[int]*MigrationStatus, error) {
+	var status []*MigrationStatus
+	_, err := db.Query(&status, "SELECT version, name, applied_at FROM schema_migrations")
+	if e, ok := err.(pg.Error); ok && e.Field('C') == pgUndefinedTable {
+		return map[int]*MigrationStatus{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get applied migrations: %v", err)
+	}
+	applied := make(map[int]*MigrationStatus, len(status))
+	for _, s := range status {
+		applied[s.Version] = s
+	}
+	return applied, nil
+}
+
+// datasetColumns are the columns of the dataset files in the order of their
+// fields. Tables without columns match the files.
+var datasetColumns = map[string][]string{
+	"vehicles": {"manufacturer_id", "id", "manufacturer_name", "trade_name", "commercial_name",
+		"allotment_date", "category", "bodywork", "power_source_id", "power",
+		"engine_capacity", "axles", "powered_axles", "seats", "maximum_mass"},
+}
+
+// loadDataset loads the embedded dataset and derives the manufacturers.
+// Databases created by the former 'schema.sql' are upgraded first.
+func loadDataset(tx *pg.Tx) error {
+	count, err := tx.Model((*Vehicle)(nil)).Count()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		log.Printf("Upgrading legacy schema, found %d vehicles\n", count)
+		if err := upgradeLegacySchema(tx); err != nil {
+			return fmt.Errorf("could not upgrade legacy schema: %v", err)
+		}
+	}
+
+	if _, err := tx.Exec("SET LOCAL DateStyle = 'German'"); err != nil {
+		return err
+	}
+	data := EmbeddedData()
+	tables := []string{"power_sources", "power_source_translations", "vehicles", "wmis", "wmi_manufacturers"}
+	for _, table := range tables {
+		file, err := data.Open(table + ".csv")
+		if err != nil {
+			return err
+		}
+		target := table
+		if columns, ok := datasetColumns[table]; ok {
+			target = fmt.Sprintf("%s (%s)", table, strings.Join(columns, ", "))
+		}
+		_, err = tx.CopyFrom(file, fmt.Sprintf("COPY %s FROM STDIN WITH (FORMAT csv, HEADER)", target))
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("could not copy %s: %v", table, err)
+		}
+	}
+
+	_, err = tx.Exec(`
+		-- a name is used in a period of consecutive allotment dates of the
+		-- manufacturer, names used at the same dates have overlapping periods
+		INSERT INTO manufacturer_names(manufacturer_id, name, valid_from, valid_to)
+		  SELECT
+		    manufacturer_id,
+		    name,
+		    min(allotment_date) AS valid_from,
+		    max(allotment_date) AS valid_to
+		  FROM (
+		    SELECT
+		      manufacturer_id,
+		      allotment_date,
+		      name,
+		      dense_rank() OVER (PARTITION BY manufacturer_id ORDER BY allotment_date) -
+		        row_number() OVER (PARTITION BY manufacturer_id, name ORDER BY allotment_date) AS period
+		    FROM (
+		      SELECT DISTINCT
+		        manufacturer_id,
+		        allotment_date,
+		        manufacturer_name AS name
+		      FROM vehicles
+		    ) AS dates
+		  ) AS periods
+		  GROUP BY manufacturer_id, name, period;
+
+		-- the current name is the longest used one of the latest date
+		INSERT INTO manufacturers(id, name)
+		  SELECT DISTINCT ON (manufacturer_id)
+		    manufacturer_id AS id,
+		    name
+		  FROM manufacturer_names
+		  ORDER BY manufacturer_id, valid_to DESC, valid_from, name;
+
+		ALTER TABLE vehicles ADD FOREIGN KEY (manufacturer_id) REFERENCES manufacturers(id);
+		ALTER TABLE vehicles ADD FOREIGN KEY (power_source_id) REFERENCES power_sources(id);
+		ALTER TABLE manufacturer_names ADD FOREIGN KEY (manufacturer_id) REFERENCES manufacturers(id);
+		ALTER TABLE power_source_translations ADD FOREIGN KEY (power_source_id) REFERENCES power_sources(id);
+		ALTER TABLE wmi_manufacturers ADD FOREIGN KEY (wmi_id) REFERENCES wmis(id);
+		ALTER TABLE wmi_manufacturers ADD FOREIGN KEY (manufacturer_id) REFERENCES manufacturers(id);
+	`)
+	return err
+}
+
+// upgradeLegacySchema upgrades the tables created by the former 'schema.sql',
+// which lack the manufacturer names of the vehicles, and empties them, so that
+// the dataset is loaded into them like into new tables. Their foreign keys are
+// dropped, since loadDataset adds them again.
+func upgradeLegacySchema(tx *pg.Tx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE vehicles
+		  DROP CONSTRAINT IF EXISTS vehicles_manufacturer_id_fkey,
+		  DROP CONSTRAINT IF EXISTS vehicles_power_source_id_fkey,
+		  ADD COLUMN IF NOT EXISTS manufacturer_name text;
+
+		TRUNCATE vehicles, manufacturers, power_sources;
+	`)
+	return err
+}
+
+// loadPowerSourceClassifications loads the embedded classification of the
+// power sources.
+func loadPowerSourceClassifications(tx *pg.Tx) error {
+	file, err := EmbeddedData().Open("power_source_classifications.csv")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = tx.CopyFrom(file, "COPY power_source_classifications FROM STDIN WITH (FORMAT csv, HEADER)")
+	if err != nil {
+		return fmt.Errorf("could not copy power_source_classifications: %v", err)
+	}
+	return nil
+}
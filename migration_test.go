@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/csv"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewMigrator(t *testing.T) {
+
+	t.Log("create migrator")
+	m, err := NewMigrator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"create_tables", "load_dataset", "add_vehicle_indexes",
+		"create_power_source_classifications", "load_power_source_classifications"}
+	if len(m.migrations) < len(want) {
+		t.Fatalf("migrations are bad, got:'%v'", m.migrations)
+	}
+	for i, name := range want {
+		if m.migrations[i].Version != i+1 || m.migrations[i].Name != name {
+			t.Fatalf("migration is bad, got:'%v %v', want:'%v %v'", m.migrations[i].Version, m.migrations[i].Name, i+1, name)
+		}
+	}
+}
+
+func TestSQLMigrationsBadName(t *testing.T) {
+
+	t.Log("read migration without version")
+	files := fstest.MapFS{"db/migrations/create_tables.sql": {Data: []byte("SELECT 1")}}
+	if _, err := sqlMigrations(files); err == nil {
+		t.Fatal("error is nil")
+	}
+}
+
+func TestDatasetColumns(t *testing.T) {
+	for table, columns := range datasetColumns {
+		t.Logf("read header of %s", table)
+		file, err := EmbeddedData().Open(table + ".csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		header, err := csv.NewReader(file).Read()
+		file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(header) != len(columns) {
+			t.Fatalf("number of columns is bad, got:'%v', want:'%v'", len(columns), len(header))
+		}
+	}
+}
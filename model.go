@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Model is a vehicle model of a manufacturer: the variants, each with its
+// own TSN, sharing the normalized commercial name, or the trade name if they
+// have none.
+type Model struct {
+	Linked
+	ID             string         `json:"id"`
+	ManufacturerID string         `json:"hsn"`
+	TradeName      string         `json:"tradeName,omitempty"`
+	CommercialName string         `json:"commercialName,omitempty"`
+	Variants       int            `json:"variants"`
+	AllotmentDates *DateRange     `json:"allotmentDates,omitempty"`
+	Power          *IntRange      `json:"power,omitempty"`
+	EngineCapacity *IntRange      `json:"engineCapacity,omitempty"`
+	PowerSources   []*PowerSource `json:"powerSources,omitempty"`
+	vehicles       []*Vehicle
+}
+
+func (m *Model) String() string {
+	bytes, _ := json.Marshal(m)
+	return string(bytes)
+}
+
+func (m *Model) identity() (string, string) {
+	return "models", m.ManufacturerID + "/" + m.ID
+}
+
+// DateRange is the range of dates of the variants of a model.
+type DateRange struct {
+	From Date `json:"from"`
+	To   Date `json:"to"`
+}
+
+// IntRange is the range of values of the variants of a model.
+type IntRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// normalizeModelName returns the name in lower case with letters and digits
+// only, so that e.g. '645 Ci' and '645CI' are the same model.
+func normalizeModelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// modelID returns the id of the model of the vehicle or an empty string if
+// the vehicle has no name. It is the commercial name, the trade name is left
+// out, since it is blank for many variants of a model, and only used if the
+// vehicle has no commercial name.
+func modelID(v *Vehicle) string {
+	if commercial := normalizeModelName(v.CommercialName); commercial != "" {
+		return commercial
+	}
+	return normalizeModelName(v.TradeName)
+}
+
+// GroupModels groups the vehicles of a manufacturer into models, ordered by
+// their id. The power sources are resolved by their id; vehicles without a
+// name are skipped.
+func GroupModels(vehicles []*Vehicle, powerSources map[int]*PowerSource) []*Model {
+	byID := map[string]*Model{}
+	var models []*Model
+	for _, v := range vehicles {
+		id := modelID(v)
+		if id == "" {
+			continue
+		}
+		m, ok := byID[id]
+		if !ok {
+			m = &Model{ID: id, ManufacturerID: v.ManufacturerID}
+			byID[id] = m
+			models = append(models, m)
+		}
+		m.vehicles = append(m.vehicles, v)
+	}
+	for _, m := range models {
+		m.summarize(powerSources)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+	return models
+}
+
+// summarize derives the names, ranges and power sources of the model from its
+// variants. The names are the most frequent spellings, blank names are not
+// counted.
+func (m *Model) summarize(powerSources map[int]*PowerSource) {
+	sort.Slice(m.vehicles, func(i, j int) bool { return m.vehicles[i].TSN < m.vehicles[j].TSN })
+	m.Variants = len(m.vehicles)
+
+	tradeNames, commercialNames := map[string]int{}, map[string]int{}
+	seen := map[int]bool{}
+	for _, v := range m.vehicles {
+		if name := strings.TrimSpace(v.TradeName); name != "" {
+			tradeNames[name]++
+		}
+		if name := strings.TrimSpace(v.CommercialName); name != "" {
+			commercialNames[name]++
+		}
+		if !v.AllotmentDate.IsZero() {
+			if m.AllotmentDates == nil {
+				m.AllotmentDates = &DateRange{v.AllotmentDate, v.AllotmentDate}
+			}
+			if v.AllotmentDate.Before(m.AllotmentDates.From) {
+				m.AllotmentDates.From = v.AllotmentDate
+			}
+			if v.AllotmentDate.After(m.AllotmentDates.To) {
+				m.AllotmentDates.To = v.AllotmentDate
+			}
+		}
+		m.Power = extendRange(m.Power, v.Power)
+		if v.EngineCapacity != nil {
+			m.EngineCapacity = extendRange(m.EngineCapacity, *v.EngineCapacity)
+		}
+		if ps, ok := powerSources[v.PowerSourceID]; ok && !seen[ps.ID] {
+			seen[ps.ID] = true
+			m.PowerSources = append(m.PowerSources, ps)
+		}
+	}
+	m.TradeName = mostFrequent(tradeNames)
+	m.CommercialName = mostFrequent(commercialNames)
+	sort.Slice(m.PowerSources, func(i, j int) bool { return m.PowerSources[i].ID < m.PowerSources[j].ID })
+}
+
+// extendRange extends the range by the value. Zero values are unknown and
+// not included.
+func extendRange(r *IntRange, value int) *IntRange {
+	switch {
+	case value == 0:
+	case r == nil:
+		r = &IntRange{value, value}
+	case value < r.Min:
+		r.Min = value
+	case value > r.Max:
+		r.Max = value
+	}
+	return r
+}
+
+// mostFrequent returns the most frequent value, the least one of equally
+// frequent values.
+func mostFrequent(counts map[string]int) string {
+	var value string
+	count := 0
+	for v, c := range counts {
+		if c > count || c == count && v < value {
+			value, count = v, c
+		}
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGroupModels(t *testing.T) {
+
+	powerSources := map[int]*PowerSource{
+		1: {ID: 1, ShortName: "Benzin"},
+		2: {ID: 2, ShortName: "Diesel"},
+	}
+	vehicles := []*Vehicle{
+		{ManufacturerID: "0005", TSN: "156", CommercialName: "645CI", AllotmentDate: NewDate(2003, time.October, 1), Power: 245, EngineCapacity: NullInt(4398), PowerSourceID: 1},
+		{ManufacturerID: "0005", TSN: "155", CommercialName: "645CI", AllotmentDate: NewDate(2003, time.July, 1), Power: 245, EngineCapacity: NullInt(4398), PowerSourceID: 1},
+		{ManufacturerID: "0005", TSN: "201", CommercialName: "645 Ci", AllotmentDate: NewDate(2005, time.January, 1), Power: 270, PowerSourceID: 2},
+		{ManufacturerID: "0005", TSN: "300", TradeName: "BMW", CommercialName: "X5"},
+		{ManufacturerID: "0005", TSN: "301", CommercialName: "X5"},
+		{ManufacturerID: "0005", TSN: "302", TradeName: "BMW"},
+		{ManufacturerID: "0005", TSN: "999"},
+	}
+
+	t.Log("group vehicles into models")
+	models := GroupModels(vehicles, powerSources)
+
+	if len(models) != 3 {
+		t.Fatalf("number of models is bad, got:'%v', want:'%v'", len(models), 3)
+	}
+	m := models[0]
+	if m.ID != "645ci" || m.CommercialName != "645CI" || m.Variants != 3 {
+		t.Fatalf("model is bad, got:'%v'", m)
+	}
+	if m.vehicles[0].TSN != "155" {
+		t.Fatalf("first variant is bad, got:'%v', want:'%v'", m.vehicles[0].TSN, "155")
+	}
+	if *m.AllotmentDates != (DateRange{NewDate(2003, time.July, 1), NewDate(2005, time.January, 1)}) {
+		t.Fatalf("allotment dates are bad, got:'%v'", m.AllotmentDates)
+	}
+	if *m.Power != (IntRange{245, 270}) || *m.EngineCapacity != (IntRange{4398, 4398}) {
+		t.Fatalf("ranges are bad, got:'%v', '%v'", m.Power, m.EngineCapacity)
+	}
+	if len(m.PowerSources) != 2 || m.PowerSources[0].ID != 1 {
+		t.Fatalf("power sources are bad, got:'%v'", m.PowerSources)
+	}
+	if m := models[1]; m.ID != "bmw" || m.TradeName != "BMW" || m.CommercialName != "" || m.Variants != 1 {
+		t.Fatalf("model without commercial name is bad, got:'%v'", m)
+	}
+	if m := models[2]; m.ID != "x5" || m.TradeName != "BMW" || m.Variants != 2 || m.Power != nil || m.AllotmentDates != nil {
+		t.Fatalf("model with blank and non-blank trade names is bad, got:'%v'", m)
+	}
+}
+
+func TestServerGetModels(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+	server.Get("/manufacturers/{hsn}/models/{model}", service.GetModel)
+	server.Get("/powerSources/{id}", service.GetPowerSource)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Log("get models")
+	rr := get("/manufacturers/0005/models")
+	AssertOkStatusCode(t, rr.Code)
+	var models []*Model
+	if err := json.Unmarshal(rr.Body.Bytes(), &models); err != nil {
+		t.Fatal(err)
+	}
+	if len(models) == 0 || len(models[0].Links) != 1 || models[0].Links[0].Relation != "canonical" {
+		t.Fatalf("models are bad, got:'%v'", rr.Body.String())
+	}
+
+	t.Log("get model")
+	rr = get("/manufacturers/0005/models/645CI")
+	if rr.Code != http.StatusMovedPermanently {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusMovedPermanently)
+	}
+	rr = get("/manufacturers/0005/models/645ci")
+	AssertOkStatusCode(t, rr.Code)
+	var model *Model
+	if err := json.Unmarshal(rr.Body.Bytes(), &model); err != nil {
+		t.Fatal(err)
+	}
+	if model.CommercialName != "645CI" || model.Variants < 2 {
+		t.Fatalf("model is bad, got:'%v'", rr.Body.String())
+	}
+	variants := 0
+	for _, link := range model.Links {
+		if link.Relation == "vehicle" {
+			variants++
+		}
+	}
+	if variants != model.Variants {
+		t.Fatalf("number of vehicle links is bad, got:'%v', want:'%v'", variants, model.Variants)
+	}
+
+	t.Log("get unknown model")
+	if rr := get("/manufacturers/0005/models/unknown"); rr.Code != http.StatusNotFound {
+		t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusNotFound)
+	}
+}
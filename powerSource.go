@@ -2,17 +2,98 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 // PowerSource is the power source of a vehicle.
 type PowerSource struct {
-	Linked      `pg:"-"`
-	ID          int    `pg:",pk" json:"id,omitempty"`
-	ShortName   string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
+	Linked         `pg:"-"`
+	ID             int                        `pg:",pk" json:"id,omitempty"`
+	ShortName      string                     `json:"name,omitempty"`
+	Description    string                     `json:"description,omitempty"`
+	Classification *PowerSourceClassification `pg:"-" json:"classification,omitempty"`
 }
 
 func (ps *PowerSource) String() string {
 	bytes, _ := json.Marshal(ps)
 	return string(bytes)
 }
+
+func (ps *PowerSource) identity() (string, string) {
+	return "powerSources", strconv.Itoa(ps.ID)
+}
+
+// Fuel families of power sources.
+const (
+	FuelFamilyPetrol       = "petrol"
+	FuelFamilyDiesel       = "diesel"
+	FuelFamilyGas          = "gas"
+	FuelFamilyEthanol      = "ethanol"
+	FuelFamilyHydrogen     = "hydrogen"
+	FuelFamilyMultiFuel    = "multi-fuel"
+	FuelFamilyHybrid       = "hybrid"
+	FuelFamilyPlugInHybrid = "plug-in-hybrid"
+	FuelFamilyElectric     = "electric"
+	FuelFamilyFuelCell     = "fuel-cell"
+	FuelFamilyOther        = "other"
+	FuelFamilyUnknown      = "unknown"
+)
+
+// FuelFamilies are all fuel families.
+var FuelFamilies = []string{
+	FuelFamilyPetrol, FuelFamilyDiesel, FuelFamilyGas, FuelFamilyEthanol,
+	FuelFamilyHydrogen, FuelFamilyMultiFuel, FuelFamilyHybrid, FuelFamilyPlugInHybrid,
+	FuelFamilyElectric, FuelFamilyFuelCell, FuelFamilyOther, FuelFamilyUnknown,
+}
+
+// Hybrid types of power sources.
+const (
+	HybridTypeNone     = "none"
+	HybridTypeMildFull = "mild-full"
+	HybridTypePlugIn   = "plug-in"
+)
+
+// PowerSourceClassification classifies a power source, e.g. whether it is a
+// plug-in hybrid, independent of its KBA code.
+type PowerSourceClassification struct {
+	PowerSourceID int `pg:",pk" json:"-"`
+	// FuelFamily is the bucket of the power source in statistics.
+	FuelFamily string `json:"fuelFamily"`
+	// EnergyCarriers are the primary energy carriers, e.g. 'petrol' and
+	// 'electricity'.
+	EnergyCarriers []string `pg:",array" json:"energyCarriers"`
+	Electrified    bool     `json:"electrified"`
+	HybridType     string   `json:"hybridType"`
+	FuelCell       bool     `json:"fuelCell"`
+	// Bivalent power sources run on either of two energy carriers.
+	Bivalent bool `json:"bivalent"`
+}
+
+// parsePowerSourceClassification parses a record of
+// 'power_source_classifications.csv'.
+func parsePowerSourceClassification(record []string) (*PowerSourceClassification, error) {
+	id, err := strconv.Atoi(record[0])
+	if err != nil {
+		return nil, err
+	}
+	c := &PowerSourceClassification{PowerSourceID: id, FuelFamily: record[1], HybridType: record[4]}
+	if !containsString(FuelFamilies, c.FuelFamily) {
+		return nil, fmt.Errorf("unknown fuel family '%s'", c.FuelFamily)
+	}
+	if c.HybridType != HybridTypeNone && c.HybridType != HybridTypeMildFull && c.HybridType != HybridTypePlugIn {
+		return nil, fmt.Errorf("unknown hybrid type '%s'", c.HybridType)
+	}
+	c.EnergyCarriers = []string{}
+	if carriers := strings.Trim(record[2], "{}"); carriers != "" {
+		c.EnergyCarriers = strings.Split(carriers, ",")
+	}
+	flags := map[int]*bool{3: &c.Electrified, 5: &c.FuelCell, 6: &c.Bivalent}
+	for column, flag := range flags {
+		if *flag, err = strconv.ParseBool(record[column]); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
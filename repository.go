@@ -1,31 +1,86 @@
 package main
 
 import (
+	"context"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
 )
 
 // Repository is the vehicle repository.
-type Repository struct{ db *pg.DB }
+type Repository interface {
+	io.Closer
+	// WithContext returns the repository querying with the context, e.g. to
+	// trace the queries as part of a request.
+	WithContext(ctx context.Context) Repository
+	GetManufacturers() ([]*Manufacturer, error)
+	GetManufacturer(id string) (*Manufacturer, error)
+	// GetManufacturersByIDs returns the manufacturers with the ids, with
+	// their names, omitting unknown ids.
+	GetManufacturersByIDs(ids []string) ([]*Manufacturer, error)
+	GetVehicles(manufacturer *Manufacturer) ([]*Vehicle, error)
+	GetVehicle(manufacturer *Manufacturer, id string, language string) (*Vehicle, error)
+	GetPowerSources(language string) ([]*PowerSource, error)
+	GetPowerSource(id string, language string) (*PowerSource, error)
+	GetWMI(id string) (*WMI, error)
+	GetManufacturersByWMI(wmi *WMI) ([]*Manufacturer, error)
+	// GetManufacturersByPowerSource returns the manufacturers of vehicles
+	// with the power source, skipping offset and returning at most limit
+	// manufacturers unless it is zero.
+	GetManufacturersByPowerSource(powerSource *PowerSource, limit, offset int) ([]*Manufacturer, error)
+	FindVehicles(filter *VehicleFilter) ([]*Vehicle, error)
+}
+
+// PostgresRepository is the vehicle repository backed by a Postgres database.
+type PostgresRepository struct{ db *pg.DB }
 
-// NewRepository creates a new Repository.
-func NewRepository(options *pg.Options) *Repository {
-	return &Repository{db: pg.Connect(options)}
+// NewPostgresRepository creates a new PostgresRepository. Its sessions use the
+// ISO DateStyle, whatever the database default is.
+func NewPostgresRepository(options *pg.Options) *PostgresRepository {
+	onConnect := options.OnConnect
+	options.OnConnect = func(conn *pg.Conn) error {
+		if _, err := conn.Exec("SET DateStyle = 'ISO, DMY'"); err != nil {
+			return err
+		}
+		if onConnect != nil {
+			return onConnect(conn)
+		}
+		return nil
+	}
+	db := pg.Connect(options)
+	db.AddQueryHook(queryTracer{database: options.Database})
+	return &PostgresRepository{db: db}
 }
 
-var _ io.Closer = (*Repository)(nil)
+var _ Repository = (*PostgresRepository)(nil)
 
 // Close closes this repository.
-func (r *Repository) Close() error {
+func (r *PostgresRepository) Close() error {
 	return r.db.Close()
 }
 
+// WithContext returns the repository querying with the context.
+func (r *PostgresRepository) WithContext(ctx context.Context) Repository {
+	return &PostgresRepository{db: r.db.WithContext(ctx)}
+}
+
+// model returns a new query of the model with the context of the database.
+func (r *PostgresRepository) model(model ...interface{}) *orm.Query {
+	return r.db.ModelContext(r.db.Context(), model...)
+}
+
+// Migrator returns the Migrator of the database.
+func (r *PostgresRepository) Migrator() (*Migrator, error) {
+	return NewMigrator(r.db)
+}
+
 // GetManufacturers returns all manufacturers.
-func (r *Repository) GetManufacturers() ([]*Manufacturer, error) {
+func (r *PostgresRepository) GetManufacturers() ([]*Manufacturer, error) {
 	var entities []*Manufacturer
-	err := r.db.Model(&entities).Select()
+	err := r.model(&entities).Select()
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return nil, ErrNotFound
@@ -36,23 +91,60 @@ func (r *Repository) GetManufacturers() ([]*Manufacturer, error) {
 }
 
 // GetManufacturer returns the specified manufacturer.
-func (r *Repository) GetManufacturer(id string) (*Manufacturer, error) {
+func (r *PostgresRepository) GetManufacturer(id string) (*Manufacturer, error) {
 	manufacturer := new(Manufacturer)
-	err := r.db.Model(manufacturer).Where("id = ? ", id).First()
+	err := r.model(manufacturer).Where("id = ? ", id).First()
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return nil, ErrNotFound
 		}
 		return nil, err
 	}
+	err = r.model(&manufacturer.Names).
+		Where("manufacturer_id = ?", manufacturer.ID).
+		Order("valid_from", "name").
+		Select()
+	if err != nil {
+		return nil, err
+	}
 	return manufacturer, nil
 }
 
+// GetManufacturersByIDs returns the manufacturers with the ids.
+func (r *PostgresRepository) GetManufacturersByIDs(ids []string) ([]*Manufacturer, error) {
+	var entities []*Manufacturer
+	if len(ids) == 0 {
+		return entities, nil
+	}
+	err := r.model(&entities).WhereIn("id IN (?)", ids).Order("id").Select()
+	if err != nil {
+		return nil, err
+	}
+	var names []*ManufacturerName
+	err = r.model(&names).
+		WhereIn("manufacturer_id IN (?)", ids).
+		Order("manufacturer_id", "valid_from", "name").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*Manufacturer, len(entities))
+	for _, m := range entities {
+		byID[m.ID] = m
+	}
+	for _, name := range names {
+		if m, ok := byID[name.ManufacturerID]; ok {
+			m.Names = append(m.Names, name)
+		}
+	}
+	return entities, nil
+}
+
 // GetVehicles returns all vehicles of the manufacturer.
-func (r *Repository) GetVehicles(manufacturer *Manufacturer) ([]*Vehicle, error) {
+func (r *PostgresRepository) GetVehicles(manufacturer *Manufacturer) ([]*Vehicle, error) {
 	var vehicles []*Vehicle
-	err := r.db.Model(&vehicles).
-		Column("id", "trade_name", "commercial_name", "allotment_date", "manufacturer_id").
+	err := r.model(&vehicles).
+		Column("id", "trade_name", "commercial_name", "allotment_date", "manufacturer_id", "manufacturer_name").
 		Where("manufacturer_id = ?", manufacturer.ID).
 		Select()
 	if err != nil {
@@ -64,11 +156,12 @@ func (r *Repository) GetVehicles(manufacturer *Manufacturer) ([]*Vehicle, error)
 	return vehicles, nil
 }
 
-// GetVehicle tries to get the specified vehicle.
-func (r *Repository) GetVehicle(manufacturer *Manufacturer, id string) (*Vehicle, error) {
+// GetVehicle tries to get the specified vehicle with its power source in the
+// specified language.
+func (r *PostgresRepository) GetVehicle(manufacturer *Manufacturer, id string, language string) (*Vehicle, error) {
 
 	vehicle := new(Vehicle)
-	err := r.db.Model(vehicle).
+	err := r.model(vehicle).
 		Relation("Manufacturer").
 		Relation("PowerSource").
 		Where("vehicle.manufacturer_id = ? AND vehicle.id = ?", manufacturer.ID, id).
@@ -80,32 +173,94 @@ func (r *Repository) GetVehicle(manufacturer *Manufacturer, id string) (*Vehicle
 		return nil, err
 	}
 
+	if language != DefaultLanguage {
+		vehicle.PowerSource, err = r.getPowerSource(vehicle.PowerSourceID, language)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := r.classifyPowerSources(vehicle.PowerSource); err != nil {
+		return nil, err
+	}
+
 	return vehicle, nil
 }
 
-// GetPowerSources gets all available power sources.
-func (r *Repository) GetPowerSources() ([]*PowerSource, error) {
+// translatePowerSources selects the power source texts in the specified
+// language, falling back to the untranslated texts.
+func translatePowerSources(q *orm.Query, language string) *orm.Query {
+	if language == DefaultLanguage {
+		return q
+	}
+	return q.ColumnExpr("power_source.*").
+		ColumnExpr("coalesce(t.short_name, power_source.short_name) AS short_name").
+		ColumnExpr("coalesce(t.description, power_source.description) AS description").
+		Join("LEFT JOIN power_source_translations AS t").
+		JoinOn("t.power_source_id = power_source.id").
+		JoinOn("t.language = ?", language)
+}
+
+// GetPowerSources gets all available power sources in the specified language.
+func (r *PostgresRepository) GetPowerSources(language string) ([]*PowerSource, error) {
 	var entities []*PowerSource
-	err := r.db.Model(&entities).Select()
+	err := translatePowerSources(r.model(&entities), language).Select()
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return nil, ErrNotFound
 		}
 		return nil, err
 	}
+	if err := r.classifyPowerSources(entities...); err != nil {
+		return nil, err
+	}
 	return entities, nil
 }
 
-// GetPowerSource gets the specified power source.
-func (r *Repository) GetPowerSource(id string) (*PowerSource, error) {
+// classifyPowerSources sets the classification of the power sources.
+func (r *PostgresRepository) classifyPowerSources(powerSources ...*PowerSource) error {
+	if len(powerSources) == 0 {
+		return nil
+	}
+	ids := make([]int, len(powerSources))
+	for i, ps := range powerSources {
+		ids[i] = ps.ID
+	}
+	var classifications []*PowerSourceClassification
+	err := r.model(&classifications).Where("power_source_id IN (?)", pg.In(ids)).Select()
+	if err != nil && err != pg.ErrNoRows {
+		return err
+	}
+	byID := make(map[int]*PowerSourceClassification, len(classifications))
+	for _, c := range classifications {
+		byID[c.PowerSourceID] = c
+	}
+	for _, ps := range powerSources {
+		ps.Classification = byID[ps.ID]
+	}
+	return nil
+}
+
+// GetPowerSource gets the specified power source in the specified language.
+func (r *PostgresRepository) GetPowerSource(id string, language string) (*PowerSource, error) {
+	nid, err := parsePowerSourceID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.getPowerSource(nid, language)
+}
+
+// parsePowerSourceID parses the id of a power source.
+func parsePowerSourceID(id string) (int, error) {
 	nid, err := strconv.ParseInt(id, 10, 32)
 	if err != nil {
-		return nil, NewErrNotFoundF("id is bad '%v'", id)
+		return 0, NewErrNotFoundF("id is bad '%v'", id)
 	}
+	return int(nid), nil
+}
 
+func (r *PostgresRepository) getPowerSource(id int, language string) (*PowerSource, error) {
 	powerSource := new(PowerSource)
-	err = r.db.Model(powerSource).
-		Where("id = ? ", nid).
+	err := translatePowerSources(r.model(powerSource), language).
+		Where("power_source.id = ?", id).
 		First()
 	if err != nil {
 		if err == pg.ErrNoRows {
@@ -113,5 +268,147 @@ func (r *Repository) GetPowerSource(id string) (*PowerSource, error) {
 		}
 		return nil, err
 	}
+	if err := r.classifyPowerSources(powerSource); err != nil {
+		return nil, err
+	}
 	return powerSource, nil
 }
+
+// GetWMI returns the specified world manufacturer identifier.
+func (r *PostgresRepository) GetWMI(id string) (*WMI, error) {
+	wmi := new(WMI)
+	err := r.model(wmi).Where("id = ?", id).First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, NewErrNotFoundF("unknown world manufacturer identifier '%v'", id)
+		}
+		return nil, err
+	}
+	return wmi, nil
+}
+
+// GetManufacturersByWMI returns the manufacturers registered for the world
+// manufacturer identifier.
+func (r *PostgresRepository) GetManufacturersByWMI(wmi *WMI) ([]*Manufacturer, error) {
+	var entities []*Manufacturer
+	err := r.model(&entities).
+		Join("JOIN wmi_manufacturers AS wm ON wm.manufacturer_id = manufacturer.id").
+		Where("wm.wmi_id = ?", wmi.ID).
+		Order("manufacturer.id").
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return entities, nil
+}
+
+// GetManufacturersByPowerSource returns the manufacturers of vehicles with the
+// power source.
+func (r *PostgresRepository) GetManufacturersByPowerSource(powerSource *PowerSource, limit, offset int) ([]*Manufacturer, error) {
+	var entities []*Manufacturer
+	err := r.model(&entities).
+		Where("manufacturer.id IN (SELECT manufacturer_id FROM vehicles WHERE power_source_id = ?)", powerSource.ID).
+		Order("manufacturer.id").
+		Limit(limit).
+		Offset(offset).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return entities, nil
+}
+
+// VehicleFilter restricts the vehicles returned by FindVehicles. Zero values
+// are not applied.
+type VehicleFilter struct {
+	// Query matches any part of the trade, commercial or manufacturer name.
+	Query          string
+	ManufacturerID string
+	PowerSourceID  *int
+	// PowerSourceIDs matches any of the power sources.
+	PowerSourceIDs []int
+	// FuelFamily matches the power sources classified into the fuel family.
+	FuelFamily     string
+	TradeName      string
+	CommercialName string
+	Category       string
+	MinPower       int
+	MaxPower       int
+	// AllotmentDateFrom and AllotmentDateTo restrict the allotment date,
+	// inclusively.
+	AllotmentDateFrom Date
+	AllotmentDateTo   Date
+	Limit             int
+	Offset            int
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns by a backslash.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns the LIKE pattern matching any string containing the
+// value literally.
+func containsPattern(value string) string {
+	return "%" + likeEscaper.Replace(value) + "%"
+}
+
+// FindVehicles returns the vehicles matching the filter.
+func (r *PostgresRepository) FindVehicles(filter *VehicleFilter) ([]*Vehicle, error) {
+	var vehicles []*Vehicle
+	q := r.model(&vehicles)
+	if filter.Query != "" {
+		pattern := containsPattern(filter.Query)
+		q = q.Where(`trade_name ILIKE ? ESCAPE '\' OR commercial_name ILIKE ? ESCAPE '\' OR manufacturer_name ILIKE ? ESCAPE '\'`,
+			pattern, pattern, pattern)
+	}
+	if filter.ManufacturerID != "" {
+		q = q.Where("manufacturer_id = ?", filter.ManufacturerID)
+	}
+	if filter.PowerSourceID != nil {
+		q = q.Where("power_source_id = ?", *filter.PowerSourceID)
+	}
+	if len(filter.PowerSourceIDs) > 0 {
+		q = q.Where("power_source_id IN (?)", pg.In(filter.PowerSourceIDs))
+	}
+	if filter.FuelFamily != "" {
+		q = q.Where("power_source_id IN (SELECT power_source_id FROM power_source_classifications WHERE fuel_family = ?)",
+			filter.FuelFamily)
+	}
+	if filter.TradeName != "" {
+		q = q.Where(`trade_name ILIKE ? ESCAPE '\'`, containsPattern(filter.TradeName))
+	}
+	if filter.CommercialName != "" {
+		q = q.Where(`commercial_name ILIKE ? ESCAPE '\'`, containsPattern(filter.CommercialName))
+	}
+	if filter.Category != "" {
+		q = q.Where("category = ?", filter.Category)
+	}
+	if filter.MinPower > 0 {
+		q = q.Where("power >= ?", filter.MinPower)
+	}
+	if filter.MaxPower > 0 {
+		q = q.Where("power <= ?", filter.MaxPower)
+	}
+	if !filter.AllotmentDateFrom.IsZero() {
+		q = q.Where("allotment_date >= ?", filter.AllotmentDateFrom)
+	}
+	if !filter.AllotmentDateTo.IsZero() {
+		q = q.Where("allotment_date <= ?", filter.AllotmentDateTo)
+	}
+	err := q.Order("manufacturer_id", "id").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return vehicles, nil
+}
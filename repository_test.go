@@ -7,9 +7,9 @@ import (
 	"testing"
 )
 
-func NewTestRepository(t *testing.T) *Repository {
+func NewTestRepository(t *testing.T) Repository {
 	t.Log("create test repository")
-	repository := NewRepository(&pg.Options{
+	repository := NewPostgresRepository(&pg.Options{
 		User:     getenv("DB_USER", "postgres"),
 		Password: getenv("DB_PASS", "postgres"),
 		Database: getenv("DB_NAME", "vehicles"),
@@ -28,7 +28,7 @@ func TestGetVehicle(t *testing.T) {
 	}
 
 	t.Log("get vehicle by id and manufacturer")
-	v, err := r.GetVehicle(m, "156")
+	v, err := r.GetVehicle(m, "156", DefaultLanguage)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -49,7 +49,7 @@ func TestGetVehicleNotFound(t *testing.T) {
 	}
 
 	t.Log("get vehicle by id and manufacturer")
-	_, err := r.GetVehicle(m, "156")
+	_, err := r.GetVehicle(m, "156", DefaultLanguage)
 	if err != ErrNotFound {
 		t.Fatal(fmt.Sprintf("%v, %T", err, err))
 	}
@@ -71,6 +71,32 @@ func TestGetManufacturer(t *testing.T) {
 	t.Log(m)
 }
 
+func TestGetManufacturerNames(t *testing.T) {
+
+	r := NewTestRepository(t)
+	for hsn, name := range map[string]string{"0710": "MERCEDES-BENZ (D)", "1005": "FORD (F)"} {
+		t.Logf("get names of manufacturer %s", hsn)
+		m, err := r.GetManufacturer(hsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !hasManufacturerName(m, name) {
+			t.Fatalf("names are bad, got:'%v', want:'%v'", m.Names, name)
+		}
+	}
+}
+
+// hasManufacturerName reports whether the manufacturer was registered under
+// the name.
+func hasManufacturerName(m *Manufacturer, name string) bool {
+	for _, n := range m.Names {
+		if n.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func TestGetManufacturerNotFound(t *testing.T) {
 
 	r := NewTestRepository(t)
@@ -87,7 +113,7 @@ func TestGetPowerSourceParserError(t *testing.T) {
 	r := NewTestRepository(t)
 
 	t.Log("get power source by id")
-	_, err := r.GetPowerSource("1x")
+	_, err := r.GetPowerSource("1x", DefaultLanguage)
 	if err == nil {
 		t.Fatal("error is nil")
 	}
@@ -109,7 +135,7 @@ func TestGetPowerSource(t *testing.T) {
 	r := NewTestRepository(t)
 
 	t.Log("get power source")
-	p, err := r.GetPowerSource("14")
+	p, err := r.GetPowerSource("14", DefaultLanguage)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -120,13 +146,43 @@ func TestGetPowerSource(t *testing.T) {
 	t.Log(p)
 }
 
+func TestGetPowerSourceTranslated(t *testing.T) {
+
+	r := NewTestRepository(t)
+
+	t.Log("get translated power source")
+	p, err := r.GetPowerSource("1", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.ShortName != "Petrol" {
+		t.Fatalf("short name is bad, got:'%v', want:'%v'", p.ShortName, "Petrol")
+	}
+	t.Log(p)
+}
+
 func TestGetPowerSourceNotFound(t *testing.T) {
 
 	r := NewTestRepository(t)
 
 	t.Log("get power source")
-	_, err := r.GetPowerSource("99999")
+	_, err := r.GetPowerSource("99999", DefaultLanguage)
 	if err != ErrNotFound {
 		t.Fatal(fmt.Sprintf("%v, %T", err, err))
 	}
 }
+
+func TestContainsPattern(t *testing.T) {
+
+	for value, want := range map[string]string{
+		"645":    `%645%`,
+		"_":      `%\_%`,
+		"100%":   `%100\%%`,
+		`A\B_C%`: `%A\\B\_C\%%`,
+	} {
+		if got := containsPattern(value); got != want {
+			t.Fatalf("pattern of '%s' is bad, got:'%v', want:'%v'", value, got, want)
+		}
+	}
+}
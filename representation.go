@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Views of vehicle listings.
+const (
+	ViewSummary = "summary"
+	ViewFull    = "full"
+)
+
+// Related objects embeddable into the vehicles of listings.
+const (
+	EmbedManufacturer = "manufacturer"
+	EmbedPowerSource  = "powerSource"
+)
+
+// vehicleSummaryFields are the fields of vehicles in the summary view.
+var vehicleSummaryFields = []string{
+	"hsn", "tsn", "manufacturerName", "tradeName", "commercialName", "allotmentDate",
+}
+
+// VehicleFields are the fields of vehicles in the full view, in the order they
+// are encoded.
+var VehicleFields = []string{
+	"hsn", "tsn", "manufacturerName", "tradeName", "commercialName", "allotmentDate",
+	"category", "bodywork", "power", "engineCapacity", "axles", "poweredAxles", "seats", "maximumMass",
+}
+
+// ListingOptions are the representation of the vehicles of a listing.
+type ListingOptions struct {
+	// View is ViewSummary or ViewFull.
+	View string
+	// Fields is a sparse fieldset replacing the fields of the view.
+	Fields []string
+	// Embed are the related objects inlined into each vehicle.
+	Embed []string
+}
+
+// parseListingOptions parses the query parameters 'view', 'fields' and
+// 'embed' of a vehicle listing, the latter two as comma-separated lists.
+func parseListingOptions(context *Context, defaultView string) (*ListingOptions, error) {
+	query := context.Request.URL.Query()
+	options := &ListingOptions{View: defaultView}
+	if value := query.Get("view"); value != "" {
+		if value != ViewSummary && value != ViewFull {
+			return nil, NewErrBadRequestF("view must be one of %s, %s: '%s'", ViewSummary, ViewFull, value)
+		}
+		options.View = value
+	}
+	for _, field := range splitList(query.Get("fields")) {
+		if !containsString(VehicleFields, field) {
+			return nil, NewErrBadRequestF("fields must be some of %s: '%s'", strings.Join(VehicleFields, ", "), field)
+		}
+		if !containsString(options.Fields, field) {
+			options.Fields = append(options.Fields, field)
+		}
+	}
+	embeddable := []string{EmbedManufacturer, EmbedPowerSource}
+	for _, embed := range splitList(query.Get("embed")) {
+		if !containsString(embeddable, embed) {
+			return nil, NewErrBadRequestF("embed must be some of %s: '%s'", strings.Join(embeddable, ", "), embed)
+		}
+		if !containsString(options.Embed, embed) {
+			options.Embed = append(options.Embed, embed)
+		}
+	}
+	return options, nil
+}
+
+// splitList splits a comma-separated list, omitting empty elements.
+func splitList(value string) []string {
+	var elements []string
+	for _, element := range strings.Split(value, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// fields returns the fields of the vehicles in the order they are encoded.
+func (o *ListingOptions) fields() []string {
+	if len(o.Fields) == 0 {
+		if o.View == ViewSummary {
+			return vehicleSummaryFields
+		}
+		return VehicleFields
+	}
+	fields := []string{}
+	for _, field := range VehicleFields {
+		if containsString(o.Fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// full reports whether the listing requires the full vehicles rather than
+// their summaries.
+func (o *ListingOptions) full() bool {
+	if len(o.Embed) > 0 {
+		return true
+	}
+	for _, field := range o.fields() {
+		if !containsString(vehicleSummaryFields, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// plain reports whether the vehicles are represented as they are, i.e. in
+// full. Summaries are restricted to their fields, since the other fields are
+// not loaded and would be encoded as unknown.
+func (o *ListingOptions) plain() bool {
+	return o.View == ViewFull && len(o.Fields) == 0 && len(o.Embed) == 0
+}
+
+// embeds reports whether the related object is embedded.
+func (o *ListingOptions) embeds(relation string) bool {
+	return containsString(o.Embed, relation)
+}
+
+// VehicleRepresentation is a vehicle of a listing restricted to some of its
+// fields, with its manufacturer and power source if embedded. Its links are
+// always included.
+type VehicleRepresentation struct {
+	Vehicle      *Vehicle      `json:"-"`
+	Fields       []string      `json:"-"`
+	Manufacturer *Manufacturer `json:"manufacturer"`
+	PowerSource  *PowerSource  `json:"powerSource"`
+}
+
+var _ json.Marshaler = (*VehicleRepresentation)(nil)
+
+func (r *VehicleRepresentation) identity() (string, string) {
+	return r.Vehicle.identity()
+}
+
+func (r *VehicleRepresentation) links() []*Link {
+	return r.Vehicle.Links
+}
+
+// describe describes the vehicle restricted to the fields, with its embedded
+// manufacturer and power source.
+func (r *VehicleRepresentation) describe() Node {
+	vehicle := &Vehicle{}
+	if bytes, err := json.Marshal(r); err != nil || json.Unmarshal(bytes, vehicle) != nil {
+		vehicle = r.Vehicle
+	}
+	vehicle.Manufacturer = r.Manufacturer
+	vehicle.PowerSource = r.PowerSource
+	return vehicle.describe()
+}
+
+// MarshalJSON is required by json.Marshaler
+func (r *VehicleRepresentation) MarshalJSON() ([]byte, error) {
+	encoded, err := json.Marshal(r.Vehicle)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &values); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	write := func(key string, value json.RawMessage) {
+		if buf.Len() > 0 {
+			buf.WriteByte(',')
+		} else {
+			buf.WriteByte('{')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	if links, ok := values["links"]; ok {
+		write("links", links)
+	}
+	for _, field := range r.Fields {
+		if value, ok := values[field]; ok {
+			write(field, value)
+		}
+	}
+	if r.Manufacturer != nil {
+		value, err := json.Marshal(r.Manufacturer)
+		if err != nil {
+			return nil, err
+		}
+		write(EmbedManufacturer, value)
+	}
+	if r.PowerSource != nil {
+		value, err := json.Marshal(r.PowerSource)
+		if err != nil {
+			return nil, err
+		}
+		write(EmbedPowerSource, value)
+	}
+	if buf.Len() == 0 {
+		buf.WriteByte('{')
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServerGetVehiclesRepresentations(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/powerSources/{id}", service.GetPowerSource)
+	server.Get("/vehicles", service.SearchVehicles)
+
+	get := func(path string) []map[string]json.RawMessage {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		AssertOkStatusCode(t, rr.Code)
+		var vehicles []map[string]json.RawMessage
+		if err := json.Unmarshal(rr.Body.Bytes(), &vehicles); err != nil {
+			t.Fatal(err)
+		}
+		if len(vehicles) == 0 {
+			t.Fatalf("vehicles are empty, got:'%v'", rr.Body.String())
+		}
+		return vehicles
+	}
+
+	t.Log("get summarized vehicles")
+	for _, v := range get("/manufacturers/0005/vehicles") {
+		for field := range v {
+			if field != "links" && !containsString(vehicleSummaryFields, field) {
+				t.Fatalf("summary is bad, got:'%v', unexpected:'%v'", v, field)
+			}
+		}
+	}
+
+	t.Log("get full vehicles")
+	vehicles := get("/manufacturers/0005/vehicles?view=full")
+	if _, ok := vehicles[0]["engineCapacity"]; !ok {
+		t.Fatalf("full vehicle is bad, got:'%v'", vehicles[0])
+	}
+
+	t.Log("get sparse fieldset")
+	for _, v := range get("/manufacturers/0005/vehicles?fields=tsn,power") {
+		if len(v) != 3 || v["links"] == nil || v["tsn"] == nil || v["tradeName"] != nil {
+			t.Fatalf("sparse vehicle is bad, got:'%v'", v)
+		}
+	}
+
+	t.Log("get vehicles with embedded manufacturer and power source")
+	vehicles = get("/vehicles?hsn=0005&limit=5&fields=tsn&embed=manufacturer,powerSource")
+	var embedded struct {
+		Manufacturer *Manufacturer `json:"manufacturer"`
+		PowerSource  *PowerSource  `json:"powerSource"`
+	}
+	bytes, _ := json.Marshal(vehicles[0])
+	if err := json.Unmarshal(bytes, &embedded); err != nil {
+		t.Fatal(err)
+	}
+	if m := embedded.Manufacturer; m == nil || m.ID != "0005" || len(m.Links) != 1 || m.Links[0].Relation != "canonical" {
+		t.Fatalf("embedded manufacturer is bad, got:'%v'", string(bytes))
+	}
+	if p := embedded.PowerSource; p == nil || p.ID == 0 || len(p.Links) != 1 {
+		t.Fatalf("embedded power source is bad, got:'%v'", string(bytes))
+	}
+
+	t.Log("get vehicles with bad options")
+	for _, query := range []string{"view=compact", "fields=tsn,color", "embed=models"} {
+		req, _ := http.NewRequest("GET", "/manufacturers/0005/vehicles?"+query, nil)
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "must be") {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
+	"fmt"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
 	"log"
@@ -10,6 +12,8 @@ import (
 	"net/url"
 	"reflect"
 	"runtime"
+	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 )
@@ -21,18 +25,72 @@ type HandlerFunc func(*Context) (interface{}, error)
 
 // Server is the HTTP server.
 type Server struct {
-	router     *mux.Router
-	routeByPtr map[uintptr]*mux.Route
+	router             *mux.Router
+	routeByPtr         map[uintptr]*mux.Route
+	logger             *logrus.Logger
+	readTimeout        time.Duration
+	writeTimeout       time.Duration
+	idleTimeout        time.Duration
+	tlsConfig          *tls.Config
+	cacheMaxAge        time.Duration
+	cors               *CORSConfig
+	encodings          []string
+	compressionMinSize int
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithLogger sets the logger of requests.
+func WithLogger(logger *logrus.Logger) ServerOption {
+	return func(s *Server) { s.logger = logger }
+}
+
+// WithTimeouts sets the timeouts of reading requests, writing responses and
+// idle keep-alive connections.
+func WithTimeouts(read, write, idle time.Duration) ServerOption {
+	return func(s *Server) {
+		s.readTimeout = read
+		s.writeTimeout = write
+		s.idleTimeout = idle
+	}
+}
+
+// WithTLS enables TLS with the configuration, if not nil.
+func WithTLS(tlsConfig *tls.Config) ServerOption {
+	return func(s *Server) { s.tlsConfig = tlsConfig }
+}
+
+// WithCacheMaxAge makes successful GET responses cacheable for maxAge.
+func WithCacheMaxAge(maxAge time.Duration) ServerOption {
+	return func(s *Server) { s.cacheMaxAge = maxAge }
+}
+
+// WithCompression compresses responses of at least minSize bytes with the
+// encodings, in order of preference, accepted by the client.
+func WithCompression(minSize int, encodings ...string) ServerOption {
+	return func(s *Server) {
+		s.compressionMinSize = minSize
+		s.encodings = encodings
+	}
+}
+
+// WithCORS allows cross-origin requests as configured.
+func WithCORS(config CORSConfig) ServerOption {
+	return func(s *Server) { s.cors = &config }
 }
 
 // NewServer creates a new Server.
-func NewServer() *Server {
+func NewServer(options ...ServerOption) *Server {
 	s := &Server{
 		routeByPtr: make(map[uintptr]*mux.Route),
 		router:     mux.NewRouter().StrictSlash(true),
+		logger:     logrus.New(),
+	}
+	for _, option := range options {
+		option(s)
 	}
 	s.router.Use(s.loggingMiddleware())
-	s.router.Use(mux.CORSMethodMiddleware(s.router))
 	s.router.MethodNotAllowedHandler = s.errorHandler(nil, ErrMethodNotAllowed)
 	s.router.NotFoundHandler = s.errorHandler(nil, ErrNotFound)
 	return s
@@ -47,36 +105,78 @@ func (s *Server) loggingMiddleware() mux.MiddlewareFunc {
 	}
 }
 
-// Get defines a HTTP GET route.
+// Get defines a HTTP GET route, that answers HEAD requests as well.
 func (s *Server) Get(path string, handlerFunc HandlerFunc) {
+	s.handle(http.MethodGet, path, handlerFunc)
+}
+
+// Post defines a HTTP POST route.
+func (s *Server) Post(path string, handlerFunc HandlerFunc) {
+	s.handle(http.MethodPost, path, handlerFunc)
+}
+
+func (s *Server) handle(method, path string, handlerFunc HandlerFunc) {
 	pc := reflect.ValueOf(handlerFunc).Pointer()
-	log.Printf("Registering route: %v\n", path)
+	log.Printf("Registering route: %v %v\n", method, path)
+	methods := []string{method}
+	if method == http.MethodGet {
+		methods = append(methods, http.MethodHead)
+	}
 	route := s.router.
 		Host("{host:.+}").
 		Path(path).
-		Name(runtime.FuncForPC(pc).Name()).
+		Name(method + " " + runtime.FuncForPC(pc).Name()).
 		Handler(s.handler(handlerFunc)).
-		Methods(http.MethodGet)
+		Methods(methods...)
 
-	s.routeByPtr[pc] = route
+	if _, ok := s.routeByPtr[pc]; !ok {
+		s.routeByPtr[pc] = route
+	}
 
 }
 
 // Start starts the server.
 func (s *Server) Start(addr string) error {
-	return http.ListenAndServe(addr, s)
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      s,
+		ReadTimeout:  s.readTimeout,
+		WriteTimeout: s.writeTimeout,
+		IdleTimeout:  s.idleTimeout,
+		TLSConfig:    s.tlsConfig,
+	}
+	if s.tlsConfig != nil {
+		log.Printf("Serving HTTPS on %v\n", addr)
+		return server.ListenAndServeTLS("", "")
+	}
+	log.Printf("Serving HTTP on %v\n", addr)
+	return server.ListenAndServe()
 }
 
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.cors != nil && s.handleCORS(w, r) {
+		return
+	}
 	s.router.ServeHTTP(w, r)
 }
 
 // Context is a HTTP context.
 type Context struct {
-	server  *Server
-	Params  map[string]string
-	Request *http.Request
-	logger  *logrus.Entry
+	server   *Server
+	Params   map[string]string
+	Request  *http.Request
+	Language string
+	logger   *logrus.Entry
+	// translated is whether the content is translated into the Language.
+	translated bool
+}
+
+// Translate returns the language to translate the content into and marks
+// the content as translated, so that its representations are negotiated by
+// Accept-Language.
+func (c *Context) Translate() string {
+	c.translated = true
+	return c.Language
 }
 
 // URL returns a URL builder function for the specified handler.
@@ -90,22 +190,7 @@ func (c *Context) URL(handler HandlerFunc) func(...string) (*url.URL, error) {
 			if err != nil {
 				return nil, err
 			}
-			prefix := c.Request.Header.Get("X-Forwarded-Prefix")
-			if prefix != "" {
-				url.Path = prefix + url.Path
-			}
-			proto := c.Request.Header.Get("X-Forwarded-Proto")
-			if proto != "" {
-				url.Scheme = proto
-			}
-			port := c.Request.Header.Get("X-Forwarded-Port")
-			if port != "" && url.Port() != port &&
-				((url.Scheme == "https" && port != "443") ||
-					(url.Scheme == "http" && port != "80")) {
-				url.Host = url.Hostname() + ":" + port
-			}
-
-			return url, nil
+			return forwarded(c.Request, url), nil
 		}
 	}
 	return func(...string) (*url.URL, error) {
@@ -113,6 +198,44 @@ func (c *Context) URL(handler HandlerFunc) func(...string) (*url.URL, error) {
 	}
 }
 
+// SelfURL returns the URL of the requested route, including its query.
+func (c *Context) SelfURL() (*url.URL, error) {
+	route := mux.CurrentRoute(c.Request)
+	if route == nil {
+		return nil, errors.New("route not found")
+	}
+	params := []string{}
+	for name, value := range mux.Vars(c.Request) {
+		params = append(params, name, value)
+	}
+	url, err := route.URL(params...)
+	if err != nil {
+		return nil, err
+	}
+	url.RawQuery = c.Request.URL.RawQuery
+	return forwarded(c.Request, url), nil
+}
+
+// forwarded applies the prefix, protocol and port a proxy forwarded the
+// request from to the URL.
+func forwarded(r *http.Request, url *url.URL) *url.URL {
+	prefix := r.Header.Get("X-Forwarded-Prefix")
+	if prefix != "" {
+		url.Path = prefix + url.Path
+	}
+	proto := r.Header.Get("X-Forwarded-Proto")
+	if proto != "" {
+		url.Scheme = proto
+	}
+	port := r.Header.Get("X-Forwarded-Port")
+	if port != "" && url.Port() != port &&
+		((url.Scheme == "https" && port != "443") ||
+			(url.Scheme == "http" && port != "80")) {
+		url.Host = url.Hostname() + ":" + port
+	}
+	return url
+}
+
 func (*Server) errorHandler(ctxlogger *logrus.Entry, err error) http.Handler {
 
 	if ctxlogger == nil {
@@ -124,7 +247,7 @@ func (*Server) errorHandler(ctxlogger *logrus.Entry, err error) http.Handler {
 		if e, ok := err.(Error); ok {
 			status = e.Status()
 		}
-		content := &struct {
+		var content interface{} = &struct {
 			StatusCode int    `json:"statusCode"`
 			StatusText string `json:"statusText"`
 			Message    string `json:"message"`
@@ -133,16 +256,25 @@ func (*Server) errorHandler(ctxlogger *logrus.Entry, err error) http.Handler {
 			StatusText: http.StatusText(status),
 			Message:    err.Error(),
 		}
-		w.Header().Set("Content-Type", "application/json")
+		mediaType := negotiateMediaType(r, []string{MediaTypeJSON, MediaTypeJSONAPI})
+		if mediaType == MediaTypeJSONAPI {
+			content = jsonAPIErrors(status, err)
+		}
+		w.Header().Set("Content-Type", mediaType)
 		w.WriteHeader(status)
+		if r.Method == http.MethodHead {
+			return
+		}
 		if err := json.NewEncoder(w).Encode(content); err != nil {
 			ctxlogger.WithError(err).Error("could not encode error response")
 		}
 	})
 }
 
+// IsCriticalError reports whether the error is an unexpected one, i.e. not an
+// Error with the status not found, e.g. a network error of the database.
 func (*Server) IsCriticalError(err error) bool {
-	if httpErr := err.(Error); httpErr.Status() != http.StatusNotFound {
+	if httpErr, ok := err.(Error); !ok || httpErr.Status() != http.StatusNotFound {
 		return true
 	}
 	return false
@@ -155,17 +287,67 @@ func (*Server) redirectHandler(redirect *Redirect) http.Handler {
 	})
 }
 
-func (*Server) contentHandler(ctxlogger *logrus.Entry, content interface{}) http.Handler {
+// contentHandler represents the content in the negotiated media type. The
+// language is the one of translated content and empty otherwise.
+func (s *Server) contentHandler(ctxlogger *logrus.Entry, language string, content interface{}, self *url.URL) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if content == nil {
 			w.WriteHeader(http.StatusNoContent)
-		} else {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			if err := json.NewEncoder(w).Encode(content); err != nil {
-				ctxlogger.WithError(err).Error("could not encode content response")
+			return
+		}
+		mediaType := negotiateMediaType(r, mediaTypes(content))
+		body, err := represent(content, mediaType, self.String())
+		if err != nil {
+			ctxlogger.WithError(err).Error("could not encode content response")
+			s.errorHandler(ctxlogger, err).ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", mediaType)
+		if language != "" {
+			w.Header().Set("Content-Language", language)
+			w.Header().Add("Vary", "Accept-Language")
+		}
+		w.Header().Add("Vary", "Accept")
+		if l, ok := content.(hyperlinked); ok {
+			for _, link := range l.links() {
+				w.Header().Add("Link", link.Header())
 			}
 		}
+		safe := r.Method == http.MethodGet || r.Method == http.MethodHead
+		if s.cacheMaxAge > 0 && safe {
+			w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.cacheMaxAge.Seconds())))
+		}
+
+		var encoding string
+		if len(s.encodings) > 0 && len(body) >= s.compressionMinSize {
+			w.Header().Add("Vary", "Accept-Encoding")
+			encoding = negotiateEncoding(r, s.encodings)
+		}
+		etag := entityTag(body, encoding)
+		w.Header().Set("ETag", etag)
+		if safe && notModified(r, etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if encoding != "" {
+			compressed, err := compress(encoding, body)
+			if err != nil {
+				ctxlogger.WithError(err).Error("could not compress content response")
+				s.errorHandler(ctxlogger, err).ServeHTTP(w, r)
+				return
+			}
+			body = compressed
+			w.Header().Set("Content-Encoding", encoding)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead {
+			return
+		}
+		if _, err := w.Write(body); err != nil {
+			ctxlogger.WithError(err).Error("could not write content response")
+		}
 	})
 }
 
@@ -177,20 +359,34 @@ func (s *Server) handler(f HandlerFunc) http.HandlerFunc {
 			requestId = uuid.NewV4().String()
 		}
 
-		logger := logrus.New()
-		ctxlogger := logger.WithFields(logrus.Fields{
-			"request-id": requestId,
-		})
+		r, span := startRequestSpan(r)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() { endRequestSpan(span, recorder.status) }()
+
+		fields := logrus.Fields{"request-id": requestId}
+		if span.SpanContext().HasTraceID() {
+			fields["trace-id"] = span.SpanContext().TraceID().String()
+		}
+		ctxlogger := s.logger.WithFields(fields)
+		w.Header().Set("X-Request-ID", requestId)
 
 		var handler http.Handler
-		content, err := f(&Context{s, mux.Vars(r), r, ctxlogger})
+		context := &Context{s, mux.Vars(r), r, NegotiateLanguage(r), ctxlogger, false}
+		content, err := f(context)
 		if err != nil {
 			handler = s.errorHandler(ctxlogger, err)
 		} else if redirect, ok := content.(*Redirect); ok {
 			handler = s.redirectHandler(redirect)
+		} else if self, err := context.SelfURL(); err != nil {
+			ctxlogger.WithError(err).Error("could not create self link")
+			handler = s.errorHandler(ctxlogger, ErrInternalServer)
 		} else {
-			handler = s.contentHandler(ctxlogger, content)
+			language := ""
+			if context.translated {
+				language = context.Language
+			}
+			handler = s.contentHandler(ctxlogger, language, content, self)
 		}
-		handler.ServeHTTP(w, r)
+		handler.ServeHTTP(recorder, r)
 	}
 }
@@ -1,17 +1,21 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-pg/pg/v9"
 )
 
 func BuildTestServer(t *testing.T) (*Server, func() error, func() error) {
 	t.Log("init new repository")
-	repository := NewRepository(&pg.Options{
+	repository := NewPostgresRepository(&pg.Options{
 		User:     getenv("DB_USER", "postgres"),
 		Password: getenv("DB_PASS", "postgres"),
 		Database: getenv("DB_NAME", "vehicles"),
@@ -21,6 +25,12 @@ func BuildTestServer(t *testing.T) (*Server, func() error, func() error) {
 	t.Log("init new service")
 	service := NewService(repository)
 
+	t.Log("init new graphql endpoint")
+	gql, err := NewGraphQL(repository)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	t.Log("init new server and add routes")
 	server := NewServer()
 
@@ -29,8 +39,18 @@ func BuildTestServer(t *testing.T) (*Server, func() error, func() error) {
 	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
 	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
 	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}/alternatives", service.GetAlternatives)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+	server.Get("/manufacturers/{hsn}/models/{model}", service.GetModel)
 	server.Get("/powerSources", service.GetPowerSources)
 	server.Get("/powerSources/{id}", service.GetPowerSource)
+	server.Get("/powerSources/{id}/vehicles", service.GetPowerSourceVehicles)
+	server.Get("/powerSources/{id}/manufacturers", service.GetPowerSourceManufacturers)
+	server.Get("/vehicles", service.SearchVehicles)
+	server.Get("/vins/{vin}", service.GetVIN)
+	server.Get("/dump", service.GetDump)
+	server.Get("/graphql", gql.Query)
+	server.Post("/graphql", gql.Query)
 
 	return server, repository.Close, service.Close
 }
@@ -64,7 +84,7 @@ func TestServerRoot(t *testing.T) {
 
 	AssertOkStatusCode(t, rr.Code)
 
-	want := `{"links":[{"href":"https://processing.envirocar.org/vehicles/manufacturers","type":"application/json","title":"Manufacturers","rel":"manufacturers"},{"href":"https://processing.envirocar.org/vehicles/powerSources","type":"application/json","title":"Power Sources","rel":"powerSources"}]}`
+	want := `{"links":[{"href":"https://processing.envirocar.org/vehicles/manufacturers","type":"application/json","title":"Manufacturers","rel":"manufacturers"},{"href":"https://processing.envirocar.org/vehicles/powerSources","type":"application/json","title":"Power Sources","rel":"powerSources"},{"href":"https://processing.envirocar.org/vehicles/dump","type":"text/turtle","title":"Dataset","rel":"dump"}]}`
 	AssertResponseBody(t, rr.Body.String(), want)
 
 	t.Logf("response body: %v", rr.Body.String())
@@ -93,7 +113,7 @@ func TestServerGetManufacturerById(t *testing.T) {
 
 	AssertOkStatusCode(t, rr.Code)
 
-	want := `{"links":[{"href":"http://processing.envirocar.org/manufacturers/0005/vehicles","type":"application/json","rel":"vehicles"},{"href":"http://processing.envirocar.org/manufacturers/0005","type":"application/json","title":"BMW","rel":"self"}],"hsn":"0005","name":"BMW"}`
+	want := `{"links":[{"href":"http://processing.envirocar.org/manufacturers/0005/vehicles","type":"application/json","rel":"vehicles"},{"href":"http://processing.envirocar.org/manufacturers/0005/models","type":"application/json","rel":"models"},{"href":"http://processing.envirocar.org/manufacturers/0005","type":"application/json","title":"BMW","rel":"self"}],"hsn":"0005","name":"BMW","names":[{"name":"BMW","from":"1949-11-01","to":"2019-07-15"}]}`
 	AssertResponseBody(t, rr.Body.String(), want)
 
 	t.Logf("response body: %v", rr.Body.String())
@@ -122,7 +142,41 @@ func TestServerGetPowerSourceById(t *testing.T) {
 
 	AssertOkStatusCode(t, rr.Code)
 
-	want := `{"id":14,"name":"Wasserst./Benzin/E","description":"Bivalenter Betrieb mit Wasserstoff oder Benzin kombiniert mit Elektromotor"}`
+	want := `{"links":[{"href":"http://processing.envirocar.org/powerSources/14/vehicles","type":"application/json","rel":"vehicles"},{"href":"http://processing.envirocar.org/powerSources/14/manufacturers","type":"application/json","rel":"manufacturers"},{"href":"http://processing.envirocar.org/powerSources/14","type":"application/json","title":"Wasserst./Benzin/E","rel":"self"}],"id":14,"name":"Wasserst./Benzin/E","description":"Bivalenter Betrieb mit Wasserstoff oder Benzin kombiniert mit Elektromotor","classification":{"fuelFamily":"hybrid","energyCarriers":["hydrogen","petrol","electricity"],"electrified":true,"hybridType":"mild-full","fuelCell":false,"bivalent":true}}`
+	AssertResponseBody(t, rr.Body.String(), want)
+
+	t.Logf("response body: %v", rr.Body.String())
+}
+
+func TestServerGetPowerSourceByIdTranslated(t *testing.T) {
+
+	server, repositoryClose, serviceClose := BuildTestServer(t)
+	defer repositoryClose()
+	defer serviceClose()
+
+	req, err := http.NewRequest("GET", "/powerSources/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Host = "localhost"
+	req.Host = "processing.envirocar.org"
+	req.Header.Add("Host", "processing.envirocar.org")
+	req.Header.Add("accept", "application/json")
+	req.Header.Add("accept-language", "en-US,en;q=0.9")
+
+	rr := httptest.NewRecorder()
+
+	t.Log("get translated power source by id")
+	server.ServeHTTP(rr, req)
+
+	AssertOkStatusCode(t, rr.Code)
+
+	if got := rr.Header().Get("Content-Language"); got != "en" {
+		t.Fatalf("handler returned wrong content language: got %v want %v", got, "en")
+	}
+
+	want := `{"links":[{"href":"http://processing.envirocar.org/powerSources/1/vehicles","type":"application/json","rel":"vehicles"},{"href":"http://processing.envirocar.org/powerSources/1/manufacturers","type":"application/json","rel":"manufacturers"},{"href":"http://processing.envirocar.org/powerSources/1","type":"application/json","title":"Petrol","rel":"self"}],"id":1,"name":"Petrol","description":"Petrol","classification":{"fuelFamily":"petrol","energyCarriers":["petrol"],"electrified":false,"hybridType":"none","fuelCell":false,"bivalent":false}}`
 	AssertResponseBody(t, rr.Body.String(), want)
 
 	t.Logf("response body: %v", rr.Body.String())
@@ -150,7 +204,7 @@ func TestServerGetVehicleByManufacturer(t *testing.T) {
 	server.ServeHTTP(rr, req)
 
 	AssertOkStatusCode(t, rr.Code)
-	want := `{"links":[{"href":"http://processing.envirocar.org/manufacturers/0005/vehicles/155","type":"application/json","title":"645CI","rel":"self"},{"href":"http://processing.envirocar.org/powerSources/1","type":"application/json","title":"Benzin","rel":"powerSource"},{"href":"http://processing.envirocar.org/manufacturers/0005","type":"application/json","title":"BMW","rel":"manufacturer"}],"tsn":"155","commercialName":"645CI","allotmentDate":"2003-07-01","category":"01","bodywork":"0200","power":245,"engineCapacity":4398,"axles":2,"poweredAxles":1,"seats":4,"maximumMass":2070}`
+	want := `{"links":[{"href":"http://processing.envirocar.org/manufacturers/0005/vehicles/155","type":"application/json","title":"645CI","rel":"self"},{"href":"http://processing.envirocar.org/powerSources/1","type":"application/json","title":"Benzin","rel":"powerSource"},{"href":"http://processing.envirocar.org/manufacturers/0005","type":"application/json","title":"BMW","rel":"manufacturer"},{"href":"http://processing.envirocar.org/manufacturers/0005/vehicles/155/alternatives","type":"application/json","title":"Electric and plug-in hybrid alternatives","rel":"alternatives"}],"hsn":"0005","tsn":"155","manufacturerName":"BMW","commercialName":"645CI","allotmentDate":"2003-07-01","category":"01","bodywork":"0200","power":245,"engineCapacity":4398,"axles":2,"poweredAxles":1,"seats":4,"maximumMass":2070}`
 	AssertResponseBody(t, rr.Body.String(), want)
 
 	t.Logf("response body: %v", rr.Body.String())
@@ -204,6 +258,45 @@ func TestServerGetVehiclesByManufacturer(t *testing.T) {
 	AssertOkStatusCode(t, rr.Code)
 }
 
+func TestServerSearchVehicles(t *testing.T) {
+
+	server, repositoryClose, serviceClose := BuildTestServer(t)
+	defer repositoryClose()
+	defer serviceClose()
+
+	req, err := http.NewRequest("GET", "/vehicles?q=645&hsn=0005&limit=10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Host = "processing.envirocar.org"
+	req.Header.Add("accept", "application/json")
+
+	rr := httptest.NewRecorder()
+
+	t.Log("search vehicles")
+	server.ServeHTTP(rr, req)
+
+	AssertOkStatusCode(t, rr.Code)
+
+	if !strings.Contains(rr.Body.String(), `"hsn":"0005","tsn":"155"`) {
+		t.Fatalf("handler returned unexpected body: got:'%v'", rr.Body.String())
+	}
+
+	req, err = http.NewRequest("GET", "/vehicles?limit=0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr = httptest.NewRecorder()
+
+	t.Log("search vehicles with invalid limit")
+	server.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("handler returned wrong status code: got:'%v', want:'%v'", rr.Code, http.StatusBadRequest)
+	}
+}
+
 func TestServerGetPowerSources(t *testing.T) {
 
 	server, repositoryClose, serviceClose := BuildTestServer(t)
@@ -228,6 +321,61 @@ func TestServerGetPowerSources(t *testing.T) {
 	AssertOkStatusCode(t, rr.Code)
 }
 
+func TestServerGetVIN(t *testing.T) {
+
+	server, repositoryClose, serviceClose := BuildTestServer(t)
+	defer repositoryClose()
+	defer serviceClose()
+
+	req, err := http.NewRequest("GET", "/vins/WBAEH71010B123456", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Host = "localhost"
+	req.Host = "processing.envirocar.org"
+	req.Header.Add("Host", "processing.envirocar.org")
+	req.Header.Add("accept", "application/json")
+
+	rr := httptest.NewRecorder()
+
+	t.Log("get vin")
+	server.ServeHTTP(rr, req)
+
+	AssertOkStatusCode(t, rr.Code)
+}
+
+func TestServerGraphQL(t *testing.T) {
+
+	server, repositoryClose, serviceClose := BuildTestServer(t)
+	defer repositoryClose()
+	defer serviceClose()
+
+	query := `{"query":"{ vehicle(hsn: \"0005\", tsn: \"155\") { commercialName manufacturer { name } powerSource { name } } }"}`
+	req, err := http.NewRequest("POST", "/graphql", strings.NewReader(query))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Host = "localhost"
+	req.Host = "processing.envirocar.org"
+	req.Header.Add("Host", "processing.envirocar.org")
+	req.Header.Add("accept", "application/json")
+	req.Header.Add("content-type", "application/json")
+
+	rr := httptest.NewRecorder()
+
+	t.Log("post graphql query")
+	server.ServeHTTP(rr, req)
+
+	AssertOkStatusCode(t, rr.Code)
+
+	want := `{"data":{"vehicle":{"commercialName":"645CI","manufacturer":{"name":"BMW"},"powerSource":{"name":"Benzin"}}}}`
+	AssertResponseBody(t, rr.Body.String(), want)
+
+	t.Logf("response body: %v", rr.Body.String())
+}
+
 func AssertOkStatusCode(t *testing.T, code int) {
 	// Check the status code is what we expect.
 	if code != http.StatusOK {
@@ -243,3 +391,274 @@ func AssertResponseBody(t *testing.T, got, want string) {
 			got, want)
 	}
 }
+
+func TestServerCacheAndOrigin(t *testing.T) {
+
+	server := NewServer(
+		WithCacheMaxAge(time.Hour),
+		WithCORS(CORSConfig{AllowedOrigins: []string{"https://*.envirocar.org"}}),
+	)
+	server.Get("/ping", func(*Context) (interface{}, error) {
+		return map[string]string{"status": "ok"}, nil
+	})
+
+	req, err := http.NewRequest("GET", "/ping", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "processing.envirocar.org"
+	req.Header.Add("Origin", "https://dashboard.envirocar.org")
+
+	rr := httptest.NewRecorder()
+
+	t.Log("get cacheable content cross-origin")
+	server.ServeHTTP(rr, req)
+
+	AssertOkStatusCode(t, rr.Code)
+
+	if got := rr.Header().Get("Cache-Control"); got != "public, max-age=3600" {
+		t.Fatalf("cache control is bad, got:'%v', want:'%v'", got, "public, max-age=3600")
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "https://dashboard.envirocar.org" {
+		t.Fatalf("allowed origin is bad, got:'%v', want:'%v'", got, "https://dashboard.envirocar.org")
+	}
+
+	req.Header.Set("Origin", "https://example.org")
+	rr = httptest.NewRecorder()
+
+	t.Log("get content from other origin")
+	server.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("allowed origin is bad, got:'%v', want:'%v'", got, "")
+	}
+}
+
+func TestServerCanonicalRedirect(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+
+	for path, want := range map[string]string{
+		"/manufacturers/5":                     "https://processing.envirocar.org/manufacturers/0005",
+		"/manufacturers/%205/vehicles?lang=en": "https://processing.envirocar.org/manufacturers/0005/vehicles?lang=en",
+		"/manufacturers/0005/vehicles/a%20bc":  "https://processing.envirocar.org/manufacturers/0005/vehicles/ABC",
+		"/manufacturers/603/vehicles/abc":      "https://processing.envirocar.org/manufacturers/0603/vehicles/ABC",
+	} {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		req.Header.Add("X-Forwarded-Proto", "https")
+		rr := httptest.NewRecorder()
+
+		t.Logf("get non-canonical '%s'", path)
+		server.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusMovedPermanently {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, http.StatusMovedPermanently)
+		}
+		if got := rr.Header().Get("Location"); got != want {
+			t.Fatalf("location is bad, got:'%v', want:'%v'", got, want)
+		}
+	}
+
+	for path, want := range map[string]int{
+		"/manufacturers/0005": http.StatusOK,
+		"/manufacturers/5x":   http.StatusNotFound,
+	} {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+
+		t.Logf("get '%s'", path)
+		server.ServeHTTP(rr, req)
+
+		if rr.Code != want {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, want)
+		}
+	}
+}
+
+func TestServerIsCriticalError(t *testing.T) {
+
+	server := NewServer()
+	for _, test := range []struct {
+		err      error
+		critical bool
+	}{
+		{ErrNotFound, false},
+		{NewErrBadRequestF("bad"), true},
+		{ErrInternalServer, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+	} {
+		if got := server.IsCriticalError(test.err); got != test.critical {
+			t.Fatalf("criticality of '%v' is bad, got:'%v', want:'%v'", test.err, got, test.critical)
+		}
+	}
+}
+
+func TestServerSearchVehiclesFilters(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/vehicles", service.SearchVehicles)
+
+	for path, want := range map[string]int{
+		"/vehicles?hsn=0005&fuelFamily=plug-in-hybrid": http.StatusOK,
+		"/vehicles?fuelFamily=steam":                   http.StatusBadRequest,
+		"/vehicles?allotmentDateFrom=2019-01-01":       http.StatusOK,
+		"/vehicles?allotmentDateTo=01.01.2019":         http.StatusBadRequest,
+	} {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+
+		t.Logf("search '%s'", path)
+		server.ServeHTTP(rr, req)
+
+		if rr.Code != want {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, want)
+		}
+		if want == http.StatusOK && !strings.Contains(rr.Body.String(), `"tsn"`) {
+			t.Fatalf("handler returned unexpected body: got:'%v'", rr.Body.String())
+		}
+	}
+}
+
+func TestServerGetPowerSourceNavigation(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles/{tsn}", service.GetVehicle)
+	server.Get("/powerSources/{id}", service.GetPowerSource)
+	server.Get("/powerSources/{id}/vehicles", service.GetPowerSourceVehicles)
+	server.Get("/powerSources/{id}/manufacturers", service.GetPowerSourceManufacturers)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("GET", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Log("get power source")
+	rr := get("/powerSources/4")
+	AssertOkStatusCode(t, rr.Code)
+	for _, want := range []string{
+		`{"href":"http://processing.envirocar.org/powerSources/4/vehicles","type":"application/json","rel":"vehicles"}`,
+		`{"href":"http://processing.envirocar.org/powerSources/4/manufacturers","type":"application/json","rel":"manufacturers"}`,
+		`"rel":"self"`,
+	} {
+		if !strings.Contains(rr.Body.String(), want) {
+			t.Fatalf("link is missing, got:'%v', want:'%v'", rr.Body.String(), want)
+		}
+	}
+
+	t.Log("get vehicles by power source")
+	rr = get("/powerSources/4/vehicles?limit=2&offset=1")
+	AssertOkStatusCode(t, rr.Code)
+	var vehicles []*Vehicle
+	if err := json.Unmarshal(rr.Body.Bytes(), &vehicles); err != nil {
+		t.Fatal(err)
+	}
+	if len(vehicles) != 2 {
+		t.Fatalf("number of vehicles is bad, got:'%v', want:'%v'", len(vehicles), 2)
+	}
+	for _, v := range vehicles {
+		if len(v.Links) != 1 || v.Links[0].Relation != "canonical" {
+			t.Fatalf("vehicle links are bad, got:'%v'", rr.Body.String())
+		}
+	}
+
+	t.Log("get manufacturers by power source")
+	rr = get("/powerSources/4/manufacturers")
+	AssertOkStatusCode(t, rr.Code)
+	if !strings.Contains(rr.Body.String(), `"hsn":"0005"`) {
+		t.Fatalf("manufacturers are bad, got:'%v'", rr.Body.String())
+	}
+
+	for path, want := range map[string]int{
+		"/powerSources/4/vehicles?offset=-1":    http.StatusBadRequest,
+		"/powerSources/4/manufacturers?limit=0": http.StatusBadRequest,
+		"/powerSources/99999/vehicles":          http.StatusNotFound,
+	} {
+		t.Logf("get '%s'", path)
+		if rr := get(path); rr.Code != want {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", rr.Code, want)
+		}
+	}
+}
+
+func TestServerHeadAndLinkHeaders(t *testing.T) {
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "processing.envirocar.org"
+		rr := httptest.NewRecorder()
+		server.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Log("get manufacturer")
+	get := request(http.MethodGet, "/manufacturers/0005")
+	AssertOkStatusCode(t, get.Code)
+	links := get.Header().Values("Link")
+	want := []string{
+		`<http://processing.envirocar.org/manufacturers/0005/vehicles>; rel="vehicles"; type="application/json"`,
+		`<http://processing.envirocar.org/manufacturers/0005/models>; rel="models"; type="application/json"`,
+		`<http://processing.envirocar.org/manufacturers/0005>; rel="self"; type="application/json"; title="BMW"`,
+	}
+	if len(links) != len(want) {
+		t.Fatalf("link headers are bad, got:'%v', want:'%v'", links, want)
+	}
+	for i, link := range links {
+		if link != want[i] {
+			t.Fatalf("link header is bad, got:'%v', want:'%v'", link, want[i])
+		}
+	}
+
+	t.Log("head manufacturer")
+	head := request(http.MethodHead, "/manufacturers/0005")
+	AssertOkStatusCode(t, head.Code)
+	if head.Body.Len() != 0 {
+		t.Fatalf("body is bad, got:'%v', want empty", head.Body.String())
+	}
+	for _, name := range []string{"Content-Type", "Content-Length", "ETag", "Link"} {
+		if got, want := strings.Join(head.Header().Values(name), ", "), strings.Join(get.Header().Values(name), ", "); got != want {
+			t.Fatalf("header %s is bad, got:'%v', want:'%v'", name, got, want)
+		}
+	}
+
+	t.Log("head unknown manufacturer")
+	head = request(http.MethodHead, "/manufacturers/000x")
+	if head.Code != http.StatusNotFound || head.Body.Len() != 0 {
+		t.Fatalf("response is bad, got:'%v' '%v', want:'%v'", head.Code, head.Body.String(), http.StatusNotFound)
+	}
+}
@@ -3,15 +3,16 @@ package main
 import (
 	"io"
 	"strconv"
+	"strings"
 )
 
 // Service is the vehicle service.
-type Service struct{ repository *Repository }
+type Service struct{ repository Repository }
 
 var _ io.Closer = (*Service)(nil)
 
 // NewService creates a new Service
-func NewService(repository *Repository) *Service {
+func NewService(repository Repository) *Service {
 	return &Service{repository}
 }
 
@@ -23,6 +24,9 @@ func (s *Service) Close() error {
 // GetRoot returns the root content.
 func (s *Service) GetRoot(context *Context) (interface{}, error) {
 
+	_, span := s.trace(context, "GetRoot")
+	defer span.End()
+
 	context.logger.Info("get root")
 
 	links := &Linked{}
@@ -39,9 +43,56 @@ func (s *Service) GetRoot(context *Context) (interface{}, error) {
 		return nil, ErrInternalServer
 	}
 	links.AddLink(NewLink(href, "powerSources", "application/json", "Power Sources"))
+
+	href, err = context.URL(s.GetDump)()
+	if err != nil {
+		context.logger.WithError(err).Error("could not create dump links")
+		return nil, ErrInternalServer
+	}
+	links.AddLink(NewLink(href, "dump", MediaTypeTurtle, "Dataset"))
 	return links, nil
 }
 
+// normalizeHSN returns the canonical form of the HSN: four digits, padded
+// with leading zeros, without whitespace. Other values are returned as is.
+func normalizeHSN(hsn string) string {
+	hsn = strings.Join(strings.Fields(hsn), "")
+	if hsn == "" || len(hsn) > 4 {
+		return hsn
+	}
+	for _, c := range hsn {
+		if c < '0' || c > '9' {
+			return hsn
+		}
+	}
+	return strings.Repeat("0", 4-len(hsn)) + hsn
+}
+
+// normalizeTSN returns the canonical form of the TSN: upper case, without
+// whitespace.
+func normalizeTSN(tsn string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(tsn), ""))
+}
+
+// canonicalRedirect returns a permanent redirect to the URL of the handler
+// with the canonical params, keeping the query, or nil if the requested params
+// are canonical.
+func (s *Service) canonicalRedirect(context *Context, handler HandlerFunc, pairs ...string) (*Redirect, error) {
+	canonical := true
+	for i := 0; i+1 < len(pairs); i += 2 {
+		canonical = canonical && context.Params[pairs[i]] == pairs[i+1]
+	}
+	if canonical {
+		return nil, nil
+	}
+	href, err := context.URL(handler)(pairs...)
+	if err != nil {
+		return nil, err
+	}
+	href.RawQuery = context.Request.URL.RawQuery
+	return MovedPermanently(href), nil
+}
+
 func (s *Service) manufacturerLink(context *Context, m *Manufacturer, relation string) (*Link, error) {
 	href, err := context.URL(s.GetManufacturer)("hsn", m.ID)
 	return NewLink(href, relation, "application/json", m.Name), err
@@ -57,12 +108,71 @@ func (s *Service) vehicleLink(context *Context, vehicle *Vehicle, relation strin
 	return NewLink(href, relation, "application/json", vehicle.CommercialName), err
 }
 
+// representVehicles represents the vehicles of a listing as specified by the
+// options, embedding their manufacturers and power sources with canonical
+// links.
+func (s *Service) representVehicles(context *Context, repository Repository, vehicles []*Vehicle, options *ListingOptions) (interface{}, error) {
+	if options.plain() {
+		return vehicles, nil
+	}
+
+	manufacturers := map[string]*Manufacturer{}
+	powerSources := map[int]*PowerSource{}
+	if options.embeds(EmbedPowerSource) {
+		all, err := repository.GetPowerSources(context.Translate())
+		if err != nil {
+			context.logger.WithError(err).Error("could not get power sources")
+			return nil, ErrInternalServer
+		}
+		for _, p := range all {
+			link, err := s.powerSourceLink(context, p, "canonical")
+			if err != nil {
+				context.logger.WithError(err).Error("could not create power source link")
+				return nil, ErrInternalServer
+			}
+			p.AddLink(link)
+			powerSources[p.ID] = p
+		}
+	}
+
+	fields := options.fields()
+	representations := make([]*VehicleRepresentation, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		r := &VehicleRepresentation{Vehicle: vehicle, Fields: fields}
+		if options.embeds(EmbedManufacturer) {
+			m, ok := manufacturers[vehicle.ManufacturerID]
+			if !ok {
+				var err error
+				m, err = repository.GetManufacturer(vehicle.ManufacturerID)
+				if err != nil {
+					context.logger.WithError(err).Errorf("could not get manufacturer by id: '%s'", vehicle.ManufacturerID)
+					return nil, ErrInternalServer
+				}
+				link, err := s.manufacturerLink(context, m, "canonical")
+				if err != nil {
+					context.logger.WithError(err).Error("could not create manufacturer link")
+					return nil, ErrInternalServer
+				}
+				m.AddLink(link)
+				manufacturers[m.ID] = m
+			}
+			r.Manufacturer = m
+		}
+		r.PowerSource = powerSources[vehicle.PowerSourceID]
+		representations = append(representations, r)
+	}
+	return representations, nil
+}
+
 // GetManufacturers returns all manufacturers.
 func (s *Service) GetManufacturers(context *Context) (interface{}, error) {
 
+	repository, span := s.trace(context, "GetManufacturers")
+	defer span.End()
+
 	context.logger.Info("get manufacturers")
 
-	entities, err := s.repository.GetManufacturers()
+	entities, err := repository.GetManufacturers()
 	if err != nil {
 		if context.server.IsCriticalError(err)  {
 			context.logger.WithError(err).Error("could not get manufacturers")
@@ -86,11 +196,21 @@ func (s *Service) GetManufacturers(context *Context) (interface{}, error) {
 // GetManufacturer returns the specified manufacturer.
 func (s *Service) GetManufacturer(context *Context) (interface{}, error) {
 
-	hsn := context.Params["hsn"]
+	repository, span := s.trace(context, "GetManufacturer")
+	defer span.End()
+
+	hsn := normalizeHSN(context.Params["hsn"])
+	redirect, err := s.canonicalRedirect(context, s.GetManufacturer, "hsn", hsn)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create canonical manufacturer link")
+		return nil, ErrInternalServer
+	} else if redirect != nil {
+		return redirect, nil
+	}
 
 	context.logger.Infof("get manufacturer by id: '%s'", hsn)
 
-	m, err := s.repository.GetManufacturer(hsn)
+	m, err := repository.GetManufacturer(hsn)
 	if err != nil {
 		if context.server.IsCriticalError(err)  {
 			context.logger.WithError(err).Error("could not get manufacturer")
@@ -106,6 +226,13 @@ func (s *Service) GetManufacturer(context *Context) (interface{}, error) {
 	}
 	m.AddLink(NewLink(href, "vehicles", "application/json", ""))
 
+	href, err = context.URL(s.GetModels)("hsn", m.ID)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create models links")
+		return nil, ErrInternalServer
+	}
+	m.AddLink(NewLink(href, "models", "application/json", ""))
+
 	link, err := s.manufacturerLink(context, m, "self")
 	if err != nil {
 		context.logger.WithError(err).Error("could not create manufacturer self link")
@@ -116,14 +243,30 @@ func (s *Service) GetManufacturer(context *Context) (interface{}, error) {
 	return m, nil
 }
 
-// GetVehicles returns all vehicles of the manufacturer.
+// GetVehicles returns all vehicles of the manufacturer, summarized unless
+// requested otherwise by the query parameters 'view', 'fields' and 'embed'.
 func (s *Service) GetVehicles(context *Context) (interface{}, error) {
 
+	repository, span := s.trace(context, "GetVehicles")
+	defer span.End()
+
 	context.logger.Infof("get vehicles")
 
-	hsn := context.Params["hsn"]
+	options, err := parseListingOptions(context, ViewSummary)
+	if err != nil {
+		return nil, err
+	}
 
-	m, err := s.repository.GetManufacturer(hsn)
+	hsn := normalizeHSN(context.Params["hsn"])
+	redirect, err := s.canonicalRedirect(context, s.GetVehicles, "hsn", hsn)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create canonical vehicles link")
+		return nil, ErrInternalServer
+	} else if redirect != nil {
+		return redirect, nil
+	}
+
+	m, err := repository.GetManufacturer(hsn)
 	if err != nil {
 		if context.server.IsCriticalError(err) {
 			context.logger.WithError(err).Errorf("could not get manufacturer by id: '%s'", hsn)
@@ -132,7 +275,12 @@ func (s *Service) GetVehicles(context *Context) (interface{}, error) {
 		return nil, err
 	}
 
-	vehicles, err := s.repository.GetVehicles(m)
+	var vehicles []*Vehicle
+	if options.full() {
+		vehicles, err = repository.FindVehicles(&VehicleFilter{ManufacturerID: m.ID})
+	} else {
+		vehicles, err = repository.GetVehicles(m)
+	}
 	if err != nil {
 		if context.server.IsCriticalError(err) {
 			context.logger.WithError(err).Errorf("could not get vehicles by manufacturer: %v", m)
@@ -149,17 +297,28 @@ func (s *Service) GetVehicles(context *Context) (interface{}, error) {
 		}
 		vehicle.AddLink(link)
 	}
-	return vehicles, err
+	return s.representVehicles(context, repository, vehicles, options)
 }
 
 // GetVehicle tries to get the specified vehicle.
 func (s *Service) GetVehicle(context *Context) (interface{}, error) {
 
+	repository, span := s.trace(context, "GetVehicle")
+	defer span.End()
+
 	context.logger.Infof("get vehicle by id and manufacturer")
 
-	hsn := context.Params["hsn"]
+	hsn := normalizeHSN(context.Params["hsn"])
+	tsn := normalizeTSN(context.Params["tsn"])
+	redirect, err := s.canonicalRedirect(context, s.GetVehicle, "hsn", hsn, "tsn", tsn)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create canonical vehicle link")
+		return nil, ErrInternalServer
+	} else if redirect != nil {
+		return redirect, nil
+	}
 
-	m, err := s.repository.GetManufacturer(hsn)
+	m, err := repository.GetManufacturer(hsn)
 	if err != nil {
 		if context.server.IsCriticalError(err) {
 			context.logger.WithError(err).Errorf("could not get manufacturer by id: '%s'", hsn)
@@ -168,9 +327,7 @@ func (s *Service) GetVehicle(context *Context) (interface{}, error) {
 		return nil, err
 	}
 
-	tsn := context.Params["tsn"]
-
-	v, err := s.repository.GetVehicle(m, tsn)
+	v, err := repository.GetVehicle(m, tsn, context.Translate())
 	if err != nil {
 		if context.server.IsCriticalError(err) {
 			context.logger.WithError(err).Errorf("could not get vehicle by id: '%s'", tsn)
@@ -200,15 +357,231 @@ func (s *Service) GetVehicle(context *Context) (interface{}, error) {
 	}
 	v.AddLink(link)
 
+	href, err := context.URL(s.GetAlternatives)("hsn", v.ManufacturerID, "tsn", v.TSN)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create alternatives link")
+		return nil, ErrInternalServer
+	}
+	v.AddLink(NewLink(href, "alternatives", "application/json", "Electric and plug-in hybrid alternatives"))
+
 	return v, nil
 }
 
+// maxAlternativesLimit is the maximum number of alternatives returned.
+const maxAlternativesLimit = 100
+
+// GetAlternatives returns electric and plug-in hybrid vehicles comparable to
+// the specified vehicle, ranked by their similarity and limited by 'limit'.
+func (s *Service) GetAlternatives(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetAlternatives")
+	defer span.End()
+
+	hsn := normalizeHSN(context.Params["hsn"])
+	tsn := normalizeTSN(context.Params["tsn"])
+	redirect, err := s.canonicalRedirect(context, s.GetAlternatives, "hsn", hsn, "tsn", tsn)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create canonical alternatives link")
+		return nil, ErrInternalServer
+	} else if redirect != nil {
+		return redirect, nil
+	}
+
+	context.logger.Infof("get alternatives of vehicle: '%s/%s'", hsn, tsn)
+
+	limit := 10
+	if value := context.Request.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAlternativesLimit {
+			return nil, NewErrBadRequestF("limit must be between 1 and %d: '%s'", maxAlternativesLimit, value)
+		}
+	}
+
+	m, err := repository.GetManufacturer(hsn)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get manufacturer by id: '%s'", hsn)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	v, err := repository.GetVehicle(m, tsn, context.Language)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get vehicle by id: '%s'", tsn)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	powerSources, err := repository.GetPowerSources(context.Language)
+	if err != nil {
+		context.logger.WithError(err).Error("could not get power sources")
+		return nil, ErrInternalServer
+	}
+	drives := alternativeDrives(powerSources)
+	if len(drives) == 0 {
+		return []*Alternative{}, nil
+	}
+
+	candidates, err := repository.FindVehicles(&VehicleFilter{
+		Category:       v.Category,
+		PowerSourceIDs: alternativePowerSourceIDs(drives),
+	})
+	if err != nil {
+		context.logger.WithError(err).Error("could not get alternative vehicles")
+		return nil, ErrInternalServer
+	}
+
+	alternatives := FindAlternatives(v, candidates, drives, limit)
+	for _, a := range alternatives {
+		link, err := s.vehicleLink(context, a.Vehicle, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create vehicle link")
+			return nil, ErrInternalServer
+		}
+		a.Vehicle.AddLink(link)
+	}
+	return alternatives, nil
+}
+
+func (s *Service) modelLink(context *Context, m *Model, relation string) (*Link, error) {
+	href, err := context.URL(s.GetModel)("hsn", m.ManufacturerID, "model", m.ID)
+	return NewLink(href, relation, "application/json", strings.TrimSpace(m.TradeName+" "+m.CommercialName)), err
+}
+
+// models returns the models of the manufacturer with linked power sources.
+func (s *Service) models(context *Context, repository Repository, hsn string) ([]*Model, error) {
+	m, err := repository.GetManufacturer(hsn)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get manufacturer by id: '%s'", hsn)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	vehicles, err := repository.FindVehicles(&VehicleFilter{ManufacturerID: m.ID})
+	if err != nil {
+		context.logger.WithError(err).Errorf("could not get vehicles by manufacturer: %v", m)
+		return nil, ErrInternalServer
+	}
+
+	entities, err := repository.GetPowerSources(context.Translate())
+	if err != nil {
+		context.logger.WithError(err).Error("could not get power sources")
+		return nil, ErrInternalServer
+	}
+	powerSources := make(map[int]*PowerSource, len(entities))
+	for _, ps := range entities {
+		link, err := s.powerSourceLink(context, ps, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create power source link")
+			return nil, ErrInternalServer
+		}
+		ps.AddLink(link)
+		powerSources[ps.ID] = ps
+	}
+
+	return GroupModels(vehicles, powerSources), nil
+}
+
+// GetModels returns the models of the manufacturer.
+func (s *Service) GetModels(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetModels")
+	defer span.End()
+
+	context.logger.Infof("get models")
+
+	hsn := normalizeHSN(context.Params["hsn"])
+	redirect, err := s.canonicalRedirect(context, s.GetModels, "hsn", hsn)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create canonical models link")
+		return nil, ErrInternalServer
+	} else if redirect != nil {
+		return redirect, nil
+	}
+
+	models, err := s.models(context, repository, hsn)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range models {
+		link, err := s.modelLink(context, m, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create model link")
+			return nil, ErrInternalServer
+		}
+		m.AddLink(link)
+	}
+	return models, nil
+}
+
+// GetModel returns the specified model of the manufacturer with links to its
+// variants.
+func (s *Service) GetModel(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetModel")
+	defer span.End()
+
+	hsn := normalizeHSN(context.Params["hsn"])
+	id := strings.ToLower(strings.TrimSpace(context.Params["model"]))
+	redirect, err := s.canonicalRedirect(context, s.GetModel, "hsn", hsn, "model", id)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create canonical model link")
+		return nil, ErrInternalServer
+	} else if redirect != nil {
+		return redirect, nil
+	}
+
+	context.logger.Infof("get model by id: '%s'", id)
+
+	models, err := s.models(context, repository, hsn)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range models {
+		if m.ID != id {
+			continue
+		}
+		link, err := s.modelLink(context, m, "self")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create model self link")
+			return nil, ErrInternalServer
+		}
+		m.AddLink(link)
+
+		href, err := context.URL(s.GetManufacturer)("hsn", m.ManufacturerID)
+		if err != nil {
+			context.logger.WithError(err).Error("could not create manufacturer link")
+			return nil, ErrInternalServer
+		}
+		m.AddLink(NewLink(href, "manufacturer", "application/json", ""))
+
+		for _, vehicle := range m.vehicles {
+			link, err := s.vehicleLink(context, vehicle, "vehicle")
+			if err != nil {
+				context.logger.WithError(err).Error("could not create vehicle link")
+				return nil, ErrInternalServer
+			}
+			m.AddLink(link)
+		}
+		return m, nil
+	}
+	return nil, NewErrNotFoundF("unknown model '%s'", id)
+}
+
 // GetPowerSources gets all available power sources.
 func (s *Service) GetPowerSources(context *Context) (interface{}, error) {
 
+	repository, span := s.trace(context, "GetPowerSources")
+	defer span.End()
+
 	context.logger.Infof("get power sources")
 
-	entities, err := s.repository.GetPowerSources()
+	entities, err := repository.GetPowerSources(context.Translate())
 	if err != nil {
 		if context.server.IsCriticalError(err) {
 			context.logger.WithError(err).Error("could not get power sources")
@@ -232,11 +605,14 @@ func (s *Service) GetPowerSources(context *Context) (interface{}, error) {
 // GetPowerSource gets the specified power source.
 func (s *Service) GetPowerSource(context *Context) (interface{}, error) {
 
+	repository, span := s.trace(context, "GetPowerSource")
+	defer span.End()
+
 	id := context.Params["id"]
 
 	context.logger.Infof("get power source by id: '%s'", id)
 
-	p, err := s.repository.GetPowerSource(id)
+	p, err := repository.GetPowerSource(id, context.Translate())
 	if err != nil {
 		if context.server.IsCriticalError(err) {
 			context.logger.WithError(err).Error("could not get power source")
@@ -245,5 +621,332 @@ func (s *Service) GetPowerSource(context *Context) (interface{}, error) {
 		return nil, err
 	}
 
+	href, err := context.URL(s.GetPowerSourceVehicles)("id", strconv.Itoa(p.ID))
+	if err != nil {
+		context.logger.WithError(err).Error("could not create vehicles links")
+		return nil, ErrInternalServer
+	}
+	p.AddLink(NewLink(href, "vehicles", "application/json", ""))
+
+	href, err = context.URL(s.GetPowerSourceManufacturers)("id", strconv.Itoa(p.ID))
+	if err != nil {
+		context.logger.WithError(err).Error("could not create manufacturers links")
+		return nil, ErrInternalServer
+	}
+	p.AddLink(NewLink(href, "manufacturers", "application/json", ""))
+
+	link, err := s.powerSourceLink(context, p, "self")
+	if err != nil {
+		context.logger.WithError(err).Error("could not create power source self link")
+		return nil, ErrInternalServer
+	}
+	p.AddLink(link)
+
 	return p, nil
 }
+
+// GetPowerSourceVehicles returns the vehicles with the specified power
+// source, paginated by 'limit' and 'offset' and represented as requested by
+// 'view', 'fields' and 'embed'.
+func (s *Service) GetPowerSourceVehicles(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetPowerSourceVehicles")
+	defer span.End()
+
+	id := context.Params["id"]
+
+	context.logger.Infof("get vehicles by power source: '%s'", id)
+
+	limit, offset, err := pagination(context)
+	if err != nil {
+		return nil, err
+	}
+	options, err := parseListingOptions(context, ViewFull)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := repository.GetPowerSource(id, context.Language)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get power source by id: '%s'", id)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	vehicles, err := repository.FindVehicles(&VehicleFilter{PowerSourceID: &p.ID, Limit: limit, Offset: offset})
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get vehicles by power source: %v", p)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	for _, vehicle := range vehicles {
+		link, err := s.vehicleLink(context, vehicle, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create vehicle link")
+			return nil, ErrInternalServer
+		}
+		vehicle.AddLink(link)
+	}
+	if vehicles == nil {
+		vehicles = []*Vehicle{}
+	}
+	return s.representVehicles(context, repository, vehicles, options)
+}
+
+// GetPowerSourceManufacturers returns the manufacturers of vehicles with the
+// specified power source, paginated by 'limit' and 'offset'.
+func (s *Service) GetPowerSourceManufacturers(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetPowerSourceManufacturers")
+	defer span.End()
+
+	id := context.Params["id"]
+
+	context.logger.Infof("get manufacturers by power source: '%s'", id)
+
+	limit, offset, err := pagination(context)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := repository.GetPowerSource(id, context.Language)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get power source by id: '%s'", id)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	manufacturers, err := repository.GetManufacturersByPowerSource(p, limit, offset)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get manufacturers by power source: %v", p)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	for _, m := range manufacturers {
+		link, err := s.manufacturerLink(context, m, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create manufacturer link")
+			return nil, ErrInternalServer
+		}
+		m.AddLink(link)
+	}
+	if manufacturers == nil {
+		manufacturers = []*Manufacturer{}
+	}
+	return manufacturers, nil
+}
+
+// GetVIN decodes the specified VIN and returns the candidate manufacturers.
+func (s *Service) GetVIN(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetVIN")
+	defer span.End()
+
+	context.logger.Infof("get vin: '%s'", context.Params["vin"])
+
+	vin, err := ParseVIN(context.Params["vin"])
+	if err != nil {
+		return nil, err
+	}
+
+	wmi, err := repository.GetWMI(vin.WMI.ID)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get wmi by id: '%s'", vin.WMI.ID)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+	vin.WMI = wmi
+
+	vin.Manufacturers, err = repository.GetManufacturersByWMI(wmi)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Errorf("could not get manufacturers by wmi: %v", wmi)
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	for _, m := range vin.Manufacturers {
+		link, err := s.manufacturerLink(context, m, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create manufacturer link")
+			return nil, ErrInternalServer
+		}
+		m.AddLink(link)
+	}
+
+	href, err := context.URL(s.GetVIN)("vin", vin.VIN)
+	if err != nil {
+		context.logger.WithError(err).Error("could not create vin self link")
+		return nil, ErrInternalServer
+	}
+	vin.AddLink(NewLink(href, "self", "application/json", vin.VIN))
+
+	return vin, nil
+}
+
+// Limits of paginated lists.
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// pagination parses the query parameters 'limit' and 'offset' of a paginated
+// list.
+func pagination(context *Context) (limit, offset int, err error) {
+	query := context.Request.URL.Query()
+	limit = defaultPageLimit
+	if value := query.Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return 0, 0, NewErrBadRequestF("limit must be between 1 and %d: '%s'", maxPageLimit, value)
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		offset, err = strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return 0, 0, NewErrBadRequestF("invalid offset: '%s'", value)
+		}
+	}
+	return limit, offset, nil
+}
+
+// SearchVehicles searches vehicles by the query parameters 'q' (any part of
+// the trade, commercial or manufacturer name), 'hsn', 'powerSource',
+// 'fuelFamily' and the ISO 8601 dates 'allotmentDateFrom' and
+// 'allotmentDateTo', paginated by 'limit' and 'offset' and represented as
+// requested by 'view', 'fields' and 'embed'.
+func (s *Service) SearchVehicles(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "SearchVehicles")
+	defer span.End()
+
+	query := context.Request.URL.Query()
+
+	context.logger.Infof("search vehicles: '%s'", query.Encode())
+
+	limit, offset, err := pagination(context)
+	if err != nil {
+		return nil, err
+	}
+	options, err := parseListingOptions(context, ViewFull)
+	if err != nil {
+		return nil, err
+	}
+	filter := &VehicleFilter{
+		Query:          query.Get("q"),
+		ManufacturerID: query.Get("hsn"),
+		Limit:          limit,
+		Offset:         offset,
+	}
+	if value := query.Get("powerSource"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, NewErrBadRequestF("invalid power source: '%s'", value)
+		}
+		filter.PowerSourceID = &id
+	}
+	if value := query.Get("fuelFamily"); value != "" {
+		if !containsString(FuelFamilies, value) {
+			return nil, NewErrBadRequestF("fuel family must be one of %s: '%s'", strings.Join(FuelFamilies, ", "), value)
+		}
+		filter.FuelFamily = value
+	}
+	for param, date := range map[string]*Date{
+		"allotmentDateFrom": &filter.AllotmentDateFrom,
+		"allotmentDateTo":   &filter.AllotmentDateTo,
+	} {
+		if value := query.Get(param); value != "" {
+			if *date, err = ParseDate(value); err != nil {
+				return nil, NewErrBadRequestF("invalid %s: %v", param, err)
+			}
+		}
+	}
+	vehicles, err := repository.FindVehicles(filter)
+	if err != nil {
+		if context.server.IsCriticalError(err) {
+			context.logger.WithError(err).Error("could not search vehicles")
+			return nil, ErrInternalServer
+		}
+		return nil, err
+	}
+
+	for _, vehicle := range vehicles {
+		link, err := s.vehicleLink(context, vehicle, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create vehicle link")
+			return nil, ErrInternalServer
+		}
+		vehicle.AddLink(link)
+	}
+	return s.representVehicles(context, repository, vehicles, options)
+}
+
+// GetDump returns the full dataset of manufacturers and vehicles in the
+// schema.org vocabulary as Turtle or N-Triples. It is not translated.
+func (s *Service) GetDump(context *Context) (interface{}, error) {
+
+	repository, span := s.trace(context, "GetDump")
+	defer span.End()
+
+	context.logger.Info("get dump")
+
+	manufacturers, err := repository.GetManufacturers()
+	if err != nil {
+		context.logger.WithError(err).Error("could not get manufacturers")
+		return nil, ErrInternalServer
+	}
+	powerSources, err := repository.GetPowerSources(DefaultLanguage)
+	if err != nil {
+		context.logger.WithError(err).Error("could not get power sources")
+		return nil, ErrInternalServer
+	}
+	vehicles, err := repository.FindVehicles(&VehicleFilter{})
+	if err != nil {
+		context.logger.WithError(err).Error("could not get vehicles")
+		return nil, ErrInternalServer
+	}
+
+	dataset := NewDataset()
+	manufacturersByID := map[string]*Manufacturer{}
+	for _, m := range manufacturers {
+		link, err := s.manufacturerLink(context, m, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create manufacturer link")
+			return nil, ErrInternalServer
+		}
+		m.AddLink(link)
+		manufacturersByID[m.ID] = m
+		dataset.Add(m.describe())
+	}
+	powerSourcesByID := map[int]*PowerSource{}
+	for _, p := range powerSources {
+		powerSourcesByID[p.ID] = p
+	}
+	for _, v := range vehicles {
+		link, err := s.vehicleLink(context, v, "canonical")
+		if err != nil {
+			context.logger.WithError(err).Error("could not create vehicle link")
+			return nil, ErrInternalServer
+		}
+		v.AddLink(link)
+		if m, ok := manufacturersByID[v.ManufacturerID]; ok {
+			v.Manufacturer = &Manufacturer{Linked: m.Linked, ID: m.ID, Name: m.Name}
+		}
+		v.PowerSource = powerSourcesByID[v.PowerSourceID]
+		dataset.Add(v.describe())
+	}
+	return dataset, nil
+}
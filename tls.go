@@ -0,0 +1,189 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// tlsReloadInterval is the minimum interval between checks of the
+// certificate files for changes.
+const tlsReloadInterval = 10 * time.Second
+
+// TLS versions selectable as minimum version.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Client authentication modes.
+const (
+	ClientAuthRequire       = "require"
+	ClientAuthVerifyIfGiven = "verify_if_given"
+)
+
+// tlsCipherSuite returns the id of the secure cipher suite by its name.
+func tlsCipherSuite(name string) (uint16, bool) {
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, true
+		}
+	}
+	return 0, false
+}
+
+// NewTLSConfig creates the tls.Config of the TLSConfig. The certificate and
+// the client CA bundle are reloaded when their files change.
+func NewTLSConfig(config *TLSConfig, logger *logrus.Logger) (*tls.Config, error) {
+	reloader := &certificateReloader{
+		certFile:     config.CertFile,
+		keyFile:      config.KeyFile,
+		clientCAFile: config.ClientCAFile,
+		interval:     tlsReloadInterval,
+		logger:       logger,
+	}
+	if err := reloader.load(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tlsVersions[config.MinVersion],
+		GetCertificate: reloader.GetCertificate,
+	}
+	for _, name := range config.CipherSuites {
+		id, ok := tlsCipherSuite(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite: '%s'", name)
+		}
+		tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, id)
+	}
+	if config.ClientCAFile != "" {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if config.ClientAuth == ClientAuthVerifyIfGiven {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+		tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c := tlsConfig.Clone()
+			c.GetConfigForClient = nil
+			c.ClientCAs = reloader.ClientCAs()
+			return c, nil
+		}
+	}
+	return tlsConfig, nil
+}
+
+// certificateReloader holds the certificate and the client CA bundle and
+// reloads them when the modification time of their files changes. A failed
+// reload keeps the previous ones.
+type certificateReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+	logger       *logrus.Logger
+
+	mu          sync.Mutex
+	checked     time.Time
+	modTimes    []time.Time
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+}
+
+// GetCertificate is the tls.Config.GetCertificate callback.
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.reloadIfChanged()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.certificate, nil
+}
+
+// ClientCAs returns the current client CA bundle.
+func (r *certificateReloader) ClientCAs() *x509.CertPool {
+	r.reloadIfChanged()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clientCAs
+}
+
+func (r *certificateReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	return files
+}
+
+func (r *certificateReloader) stat() ([]time.Time, error) {
+	var modTimes []time.Time
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+// load loads the certificate and the client CA bundle.
+func (r *certificateReloader) load() error {
+	modTimes, err := r.stat()
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load certificate: %v", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in client CA file '%s'", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.checked = time.Now()
+	return nil
+}
+
+func (r *certificateReloader) reloadIfChanged() {
+	r.mu.Lock()
+	if time.Since(r.checked) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	r.checked = time.Now()
+	previous := r.modTimes
+	r.mu.Unlock()
+
+	modTimes, err := r.stat()
+	if err != nil {
+		r.logger.WithError(err).Error("could not check certificate files")
+		return
+	}
+	for i := range modTimes {
+		if !modTimes[i].Equal(previous[i]) {
+			if err := r.load(); err != nil {
+				r.logger.WithError(err).Error("could not reload certificate files")
+				return
+			}
+			r.logger.Info("reloaded certificate files")
+			return
+		}
+	}
+}
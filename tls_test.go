@@ -0,0 +1,238 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// testCertificate is a certificate signed by the parent, or self-signed.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+func newTestCertificate(t *testing.T, name string, serial int64, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCertificate) write(t *testing.T, certFile, keyFile string) {
+	if err := os.WriteFile(certFile, c.certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if keyFile == "" {
+		return
+	}
+	if err := os.WriteFile(keyFile, c.keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+// serveTestTLS accepts connections with the tls.Config and completes their
+// handshakes.
+func serveTestTLS(t *testing.T, tlsConfig *tls.Config) string {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				if conn.(*tls.Conn).Handshake() == nil {
+					conn.Write([]byte("ok"))
+				}
+			}(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// dialTestTLS connects and returns the server certificate or the error of the
+// handshake.
+func dialTestTLS(addr string, tlsConfig *tls.Config) (*x509.Certificate, error) {
+	conn, err := tls.Dial("tcp", addr, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// with TLS 1.3 a rejected client certificate fails on the first read
+	if _, err := io.ReadAll(conn); err != nil {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestTLSClientCertificate(t *testing.T) {
+
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "ca", 1, nil)
+	server := newTestCertificate(t, "server", 2, ca)
+	client := newTestCertificate(t, "client", 3, ca)
+	other := newTestCertificate(t, "other", 4, nil)
+
+	config := DefaultConfig().TLS
+	config.CertFile = filepath.Join(dir, "server.crt")
+	config.KeyFile = filepath.Join(dir, "server.key")
+	config.ClientCAFile = filepath.Join(dir, "ca.crt")
+	server.write(t, config.CertFile, config.KeyFile)
+	ca.write(t, config.ClientCAFile, "")
+
+	tlsConfig, err := NewTLSConfig(&config, logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := serveTestTLS(t, tlsConfig)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+
+	t.Log("connect without client certificate")
+	if _, err := dialTestTLS(addr, &tls.Config{RootCAs: roots, ServerName: "localhost"}); err == nil {
+		t.Fatalf("connection without client certificate was accepted")
+	}
+
+	t.Log("connect with client certificate of another CA")
+	_, err = dialTestTLS(addr, &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{other.tlsCertificate(t)},
+	})
+	if err == nil {
+		t.Fatalf("connection with client certificate of another CA was accepted")
+	}
+
+	t.Log("connect with client certificate")
+	_, err = dialTestTLS(addr, &tls.Config{
+		RootCAs:      roots,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{client.tlsCertificate(t)},
+	})
+	if err != nil {
+		t.Fatalf("connection with client certificate failed, got:'%v'", err)
+	}
+}
+
+func TestTLSReload(t *testing.T) {
+
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "ca", 1, nil)
+	first := newTestCertificate(t, "first", 2, ca)
+	second := newTestCertificate(t, "second", 3, ca)
+
+	reloader := &certificateReloader{
+		certFile: filepath.Join(dir, "server.crt"),
+		keyFile:  filepath.Join(dir, "server.key"),
+		logger:   logrus.New(),
+	}
+	first.write(t, reloader.certFile, reloader.keyFile)
+	if err := reloader.load(); err != nil {
+		t.Fatal(err)
+	}
+	addr := serveTestTLS(t, &tls.Config{GetCertificate: reloader.GetCertificate})
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.certificate)
+	clientConfig := &tls.Config{RootCAs: roots, ServerName: "localhost"}
+
+	t.Log("connect with first certificate")
+	certificate, err := dialTestTLS(addr, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := certificate.Subject.CommonName; name != "first" {
+		t.Fatalf("certificate is bad, got:'%v', want:'%v'", name, "first")
+	}
+
+	t.Log("replace certificate with invalid files")
+	later := time.Now().Add(time.Minute)
+	if err := os.WriteFile(reloader.certFile, []byte("invalid"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Chtimes(reloader.certFile, later, later)
+	certificate, err = dialTestTLS(addr, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := certificate.Subject.CommonName; name != "first" {
+		t.Fatalf("certificate is bad, got:'%v', want:'%v'", name, "first")
+	}
+
+	t.Log("replace certificate with second certificate")
+	later = later.Add(time.Minute)
+	second.write(t, reloader.certFile, reloader.keyFile)
+	os.Chtimes(reloader.certFile, later, later)
+	os.Chtimes(reloader.keyFile, later, later)
+	certificate, err = dialTestTLS(addr, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := certificate.Subject.CommonName; name != "second" {
+		t.Fatalf("certificate is bad, got:'%v', want:'%v'", name, "second")
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of the service. It uses the global tracer
+// provider, that does not record spans unless set by NewTracerProvider.
+var tracer = otel.Tracer("github.com/enviroCar/vehicles")
+
+// propagator propagates the trace context of requests by the W3C
+// 'traceparent' and 'tracestate' headers.
+var propagator = propagation.TraceContext{}
+
+// NewTracerProvider creates a tracer provider exporting the spans to the
+// OTLP/HTTP endpoint of the configuration and sets it as global tracer
+// provider. It must be shut down to flush the remaining spans.
+func NewTracerProvider(config *TracingConfig) (*sdktrace.TracerProvider, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint: %v", err)
+	}
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint.Host)}
+	if endpoint.Scheme == "http" {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if path := strings.TrimSuffix(endpoint.Path, "/"); path != "" {
+		options = append(options, otlptracehttp.WithURLPath(path))
+	}
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create trace exporter: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(config.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider, nil
+}
+
+// startRequestSpan starts the span of the request as child of the propagated
+// trace context and returns the request with the span.
+func startRequestSpan(r *http.Request) (*http.Request, trace.Span) {
+	ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	name := r.Method
+	attributes := []attribute.KeyValue{
+		semconv.HTTPMethod(r.Method),
+		semconv.HTTPTarget(r.URL.RequestURI()),
+	}
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			name += " " + template
+			attributes = append(attributes, semconv.HTTPRoute(template))
+		}
+	}
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributes...))
+	return r.WithContext(ctx), span
+}
+
+// endRequestSpan ends the span of the request with the status code of the
+// response. Server errors mark the span as failed.
+func endRequestSpan(span trace.Span, status int) {
+	span.SetAttributes(semconv.HTTPStatusCode(status))
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	span.End()
+}
+
+// statusRecorder records the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// trace starts the span of a service method as child of the request span and
+// returns the repository bound to it.
+func (s *Service) trace(context *Context, method string) (Repository, trace.Span) {
+	ctx, span := tracer.Start(context.Request.Context(), "Service."+method)
+	return s.repository.WithContext(ctx), span
+}
+
+// queryTracer is the pg.QueryHook tracing the queries of a database.
+type queryTracer struct{ database string }
+
+var _ pg.QueryHook = queryTracer{}
+
+func (t queryTracer) BeforeQuery(ctx context.Context, event *pg.QueryEvent) (context.Context, error) {
+	statement, _ := event.UnformattedQuery()
+	operation := strings.ToUpper(strings.SplitN(strings.TrimSpace(statement), " ", 2)[0])
+	ctx, _ = tracer.Start(ctx, strings.TrimSpace(operation+" "+t.database),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBName(t.database),
+			semconv.DBOperation(operation),
+			semconv.DBStatement(statement),
+		))
+	return ctx, nil
+}
+
+func (t queryTracer) AfterQuery(ctx context.Context, event *pg.QueryEvent) error {
+	span := trace.SpanFromContext(ctx)
+	if event.Err != nil && event.Err != pg.ErrNoRows {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+	span.End()
+	return nil
+}
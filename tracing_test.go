@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// testCollector is an OTLP/HTTP trace collector stand-in.
+type testCollector struct {
+	mu    sync.Mutex
+	spans map[string]*tracepb.Span
+}
+
+func (c *testCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				c.spans[span.Name] = span
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+// testTracing is the tracer provider of the tests exporting to the collector.
+// It is set once, as the tracer delegates to the first global tracer provider
+// only.
+var testTracing struct {
+	once      sync.Once
+	collector *testCollector
+	provider  *sdktrace.TracerProvider
+	err       error
+}
+
+// newTestTracing returns the collector of the spans, clearing it, and the
+// tracer provider flushing to it.
+func newTestTracing(t *testing.T) (*testCollector, *sdktrace.TracerProvider) {
+	t.Log("create tracer provider")
+	testTracing.once.Do(func() {
+		testTracing.collector = &testCollector{spans: map[string]*tracepb.Span{}}
+		endpoint := httptest.NewServer(testTracing.collector)
+		config := DefaultConfig().Tracing
+		config.Endpoint = endpoint.URL
+		testTracing.provider, testTracing.err = NewTracerProvider(&config)
+	})
+	if testTracing.err != nil {
+		t.Fatal(testTracing.err)
+	}
+	testTracing.collector.mu.Lock()
+	defer testTracing.collector.mu.Unlock()
+	testTracing.collector.spans = map[string]*tracepb.Span{}
+	return testTracing.collector, testTracing.provider
+}
+
+func TestTracing(t *testing.T) {
+
+	collector, provider := newTestTracing(t)
+
+	service := NewService(NewTestMemoryRepository(t))
+	server := NewServer()
+	server.Get("/manufacturers/{hsn}", service.GetManufacturer)
+	server.Get("/manufacturers/{hsn}/vehicles", service.GetVehicles)
+	server.Get("/manufacturers/{hsn}/models", service.GetModels)
+
+	req, err := http.NewRequest("GET", "/manufacturers/0005", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Host = "processing.envirocar.org"
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	t.Log("get manufacturer with trace context")
+	rr := httptest.NewRecorder()
+	server.ServeHTTP(rr, req)
+	AssertOkStatusCode(t, rr.Code)
+
+	t.Log("export spans")
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	request, ok := collector.spans["GET /manufacturers/{hsn}"]
+	if !ok {
+		t.Fatalf("request span is missing, got:'%v'", collector.spans)
+	}
+	if got := hex.EncodeToString(request.TraceId); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("trace id is bad, got:'%v', want:'%v'", got, "4bf92f3577b34da6a3ce929d0e0e4736")
+	}
+	if got := hex.EncodeToString(request.ParentSpanId); got != "00f067aa0ba902b7" {
+		t.Fatalf("parent span id is bad, got:'%v', want:'%v'", got, "00f067aa0ba902b7")
+	}
+	method, ok := collector.spans["Service.GetManufacturer"]
+	if !ok {
+		t.Fatalf("service span is missing, got:'%v'", collector.spans)
+	}
+	if string(method.ParentSpanId) != string(request.SpanId) {
+		t.Fatalf("service span parent is bad, got:'%x', want:'%x'", method.ParentSpanId, request.SpanId)
+	}
+}
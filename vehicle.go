@@ -4,26 +4,41 @@ import "encoding/json"
 
 // Vehicle is a vehicle.
 type Vehicle struct {
-	Linked         `pg:"-"`
-	ManufacturerID string        `pg:",pk" json:"-"`
-	Manufacturer   *Manufacturer `json:"-"`
-	PowerSourceID  int           `json:"-"`
-	PowerSource    *PowerSource  `json:"-"`
-	TSN            string        `pg:"id,pk" json:"tsn,omitempty"`
-	TradeName      string        `json:"tradeName,omitempty"`
-	CommercialName string        `json:"commercialName,omitempty"`
-	AllotmentDate  string        `json:"allotmentDate,omitempty"`
-	Category       string        `json:"category,omitempty"`
-	Bodywork       string        `json:"bodywork,omitempty"`
-	Power          int           `json:"power,omitempty"`
-	EngineCapacity int           `json:"engineCapacity,omitempty"`
-	Axles          int           `json:"axles,omitempty"`
-	PoweredAxles   int           `json:"poweredAxles,omitempty"`
-	Seats          int           `json:"seats,omitempty"`
-	MaximumMass    int           `json:"maximumMass,omitempty"`
+	Linked           `pg:"-"`
+	ManufacturerID   string        `pg:",pk" json:"hsn,omitempty"`
+	Manufacturer     *Manufacturer `json:"-"`
+	PowerSourceID    int           `json:"-"`
+	PowerSource      *PowerSource  `json:"-"`
+	TSN              string        `pg:"id,pk" json:"tsn,omitempty"`
+	ManufacturerName string        `json:"manufacturerName,omitempty"`
+	TradeName        string        `json:"tradeName,omitempty"`
+	CommercialName   string        `json:"commercialName,omitempty"`
+	AllotmentDate    Date          `json:"allotmentDate"`
+	Category         string        `json:"category,omitempty"`
+	Bodywork         string        `json:"bodywork,omitempty"`
+	// Power is the maximum net power in kW. It is always known, so 0 is
+	// encoded rather than omitted.
+	Power int `json:"power"`
+	// EngineCapacity, Axles, PoweredAxles, Seats and MaximumMass are nil
+	// if unknown, e.g. the engine capacity of electric vehicles, and
+	// encoded as JSON null. Summaries omit them.
+	EngineCapacity *int `json:"engineCapacity"`
+	Axles          *int `json:"axles"`
+	PoweredAxles   *int `json:"poweredAxles"`
+	Seats          *int `json:"seats"`
+	MaximumMass    *int `json:"maximumMass"`
+}
+
+// NullInt returns a pointer to the value of a nullable attribute.
+func NullInt(value int) *int {
+	return &value
 }
 
 func (v *Vehicle) String() string {
 	bytes, _ := json.Marshal(v)
 	return string(bytes)
 }
+
+func (v *Vehicle) identity() (string, string) {
+	return "vehicles", v.ManufacturerID + "/" + v.TSN
+}
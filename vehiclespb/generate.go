@@ -0,0 +1,5 @@
+// Package vehiclespb contains the protocol buffer and gRPC definitions of the
+// vehicle service.
+package vehiclespb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative vehicles.proto
@@ -0,0 +1,1647 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: vehicles.proto
+
+package vehiclespb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Manufacturer is a vehicle manufacturer.
+type Manufacturer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hsn is the manufacturer code number.
+	Hsn  string `protobuf:"bytes,1,opt,name=hsn,proto3" json:"hsn,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// names are the names the manufacturer was registered under.
+	Names []*ManufacturerName `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *Manufacturer) Reset() {
+	*x = Manufacturer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Manufacturer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Manufacturer) ProtoMessage() {}
+
+func (x *Manufacturer) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Manufacturer.ProtoReflect.Descriptor instead.
+func (*Manufacturer) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Manufacturer) GetHsn() string {
+	if x != nil {
+		return x.Hsn
+	}
+	return ""
+}
+
+func (x *Manufacturer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Manufacturer) GetNames() []*ManufacturerName {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+// ManufacturerName is a name a manufacturer was registered under.
+type ManufacturerName struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// from is the first ISO 8601 allotment date under this name.
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to is the last ISO 8601 allotment date under this name.
+	To string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *ManufacturerName) Reset() {
+	*x = ManufacturerName{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ManufacturerName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManufacturerName) ProtoMessage() {}
+
+func (x *ManufacturerName) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManufacturerName.ProtoReflect.Descriptor instead.
+func (*ManufacturerName) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ManufacturerName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ManufacturerName) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ManufacturerName) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// Vehicle is a vehicle type.
+type Vehicle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hsn is the manufacturer code number.
+	Hsn string `protobuf:"bytes,1,opt,name=hsn,proto3" json:"hsn,omitempty"`
+	// tsn is the type code number.
+	Tsn              string `protobuf:"bytes,2,opt,name=tsn,proto3" json:"tsn,omitempty"`
+	ManufacturerName string `protobuf:"bytes,3,opt,name=manufacturer_name,json=manufacturerName,proto3" json:"manufacturer_name,omitempty"`
+	TradeName        string `protobuf:"bytes,4,opt,name=trade_name,json=tradeName,proto3" json:"trade_name,omitempty"`
+	CommercialName   string `protobuf:"bytes,5,opt,name=commercial_name,json=commercialName,proto3" json:"commercial_name,omitempty"`
+	// allotment_date is the ISO 8601 allotment date, e.g. '2003-07-01'.
+	AllotmentDate string `protobuf:"bytes,6,opt,name=allotment_date,json=allotmentDate,proto3" json:"allotment_date,omitempty"`
+	Category      string `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	Bodywork      string `protobuf:"bytes,8,opt,name=bodywork,proto3" json:"bodywork,omitempty"`
+	// power is the maximum net power in kW.
+	Power int32 `protobuf:"varint,9,opt,name=power,proto3" json:"power,omitempty"`
+	// engine_capacity is the engine capacity in cm³. It and the following
+	// attributes up to maximum_mass are unset if unknown, e.g. the engine
+	// capacity of electric vehicles.
+	EngineCapacity *int32 `protobuf:"varint,10,opt,name=engine_capacity,json=engineCapacity,proto3,oneof" json:"engine_capacity,omitempty"`
+	Axles          *int32 `protobuf:"varint,11,opt,name=axles,proto3,oneof" json:"axles,omitempty"`
+	PoweredAxles   *int32 `protobuf:"varint,12,opt,name=powered_axles,json=poweredAxles,proto3,oneof" json:"powered_axles,omitempty"`
+	Seats          *int32 `protobuf:"varint,13,opt,name=seats,proto3,oneof" json:"seats,omitempty"`
+	// maximum_mass is the technically permissible maximum mass in kg.
+	MaximumMass *int32 `protobuf:"varint,14,opt,name=maximum_mass,json=maximumMass,proto3,oneof" json:"maximum_mass,omitempty"`
+	// power_source_id is the power source code.
+	PowerSourceId int32 `protobuf:"varint,15,opt,name=power_source_id,json=powerSourceId,proto3" json:"power_source_id,omitempty"`
+	// power_source is only set by GetVehicle.
+	PowerSource *PowerSource `protobuf:"bytes,16,opt,name=power_source,json=powerSource,proto3" json:"power_source,omitempty"`
+	// manufacturer is only set by GetVehicle.
+	Manufacturer *Manufacturer `protobuf:"bytes,17,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+}
+
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Vehicle) GetHsn() string {
+	if x != nil {
+		return x.Hsn
+	}
+	return ""
+}
+
+func (x *Vehicle) GetTsn() string {
+	if x != nil {
+		return x.Tsn
+	}
+	return ""
+}
+
+func (x *Vehicle) GetManufacturerName() string {
+	if x != nil {
+		return x.ManufacturerName
+	}
+	return ""
+}
+
+func (x *Vehicle) GetTradeName() string {
+	if x != nil {
+		return x.TradeName
+	}
+	return ""
+}
+
+func (x *Vehicle) GetCommercialName() string {
+	if x != nil {
+		return x.CommercialName
+	}
+	return ""
+}
+
+func (x *Vehicle) GetAllotmentDate() string {
+	if x != nil {
+		return x.AllotmentDate
+	}
+	return ""
+}
+
+func (x *Vehicle) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Vehicle) GetBodywork() string {
+	if x != nil {
+		return x.Bodywork
+	}
+	return ""
+}
+
+func (x *Vehicle) GetPower() int32 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *Vehicle) GetEngineCapacity() int32 {
+	if x != nil && x.EngineCapacity != nil {
+		return *x.EngineCapacity
+	}
+	return 0
+}
+
+func (x *Vehicle) GetAxles() int32 {
+	if x != nil && x.Axles != nil {
+		return *x.Axles
+	}
+	return 0
+}
+
+func (x *Vehicle) GetPoweredAxles() int32 {
+	if x != nil && x.PoweredAxles != nil {
+		return *x.PoweredAxles
+	}
+	return 0
+}
+
+func (x *Vehicle) GetSeats() int32 {
+	if x != nil && x.Seats != nil {
+		return *x.Seats
+	}
+	return 0
+}
+
+func (x *Vehicle) GetMaximumMass() int32 {
+	if x != nil && x.MaximumMass != nil {
+		return *x.MaximumMass
+	}
+	return 0
+}
+
+func (x *Vehicle) GetPowerSourceId() int32 {
+	if x != nil {
+		return x.PowerSourceId
+	}
+	return 0
+}
+
+func (x *Vehicle) GetPowerSource() *PowerSource {
+	if x != nil {
+		return x.PowerSource
+	}
+	return nil
+}
+
+func (x *Vehicle) GetManufacturer() *Manufacturer {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return nil
+}
+
+// PowerSource is the power source of a vehicle.
+type PowerSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             int32                      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description    string                     `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Classification *PowerSourceClassification `protobuf:"bytes,4,opt,name=classification,proto3" json:"classification,omitempty"`
+}
+
+func (x *PowerSource) Reset() {
+	*x = PowerSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PowerSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerSource) ProtoMessage() {}
+
+func (x *PowerSource) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerSource.ProtoReflect.Descriptor instead.
+func (*PowerSource) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PowerSource) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PowerSource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PowerSource) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PowerSource) GetClassification() *PowerSourceClassification {
+	if x != nil {
+		return x.Classification
+	}
+	return nil
+}
+
+// PowerSourceClassification is the classification of a power source.
+type PowerSourceClassification struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// fuel_family is e.g. 'petrol', 'hybrid', 'plug-in-hybrid' or 'electric'.
+	FuelFamily string `protobuf:"bytes,1,opt,name=fuel_family,json=fuelFamily,proto3" json:"fuel_family,omitempty"`
+	// energy_carriers are the primary energy carriers, e.g. 'petrol' and
+	// 'electricity'.
+	EnergyCarriers []string `protobuf:"bytes,2,rep,name=energy_carriers,json=energyCarriers,proto3" json:"energy_carriers,omitempty"`
+	Electrified    bool     `protobuf:"varint,3,opt,name=electrified,proto3" json:"electrified,omitempty"`
+	// hybrid_type is one of 'none', 'mild-full' and 'plug-in'.
+	HybridType string `protobuf:"bytes,4,opt,name=hybrid_type,json=hybridType,proto3" json:"hybrid_type,omitempty"`
+	FuelCell   bool   `protobuf:"varint,5,opt,name=fuel_cell,json=fuelCell,proto3" json:"fuel_cell,omitempty"`
+	// bivalent power sources run on either of two energy carriers.
+	Bivalent bool `protobuf:"varint,6,opt,name=bivalent,proto3" json:"bivalent,omitempty"`
+}
+
+func (x *PowerSourceClassification) Reset() {
+	*x = PowerSourceClassification{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PowerSourceClassification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerSourceClassification) ProtoMessage() {}
+
+func (x *PowerSourceClassification) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerSourceClassification.ProtoReflect.Descriptor instead.
+func (*PowerSourceClassification) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PowerSourceClassification) GetFuelFamily() string {
+	if x != nil {
+		return x.FuelFamily
+	}
+	return ""
+}
+
+func (x *PowerSourceClassification) GetEnergyCarriers() []string {
+	if x != nil {
+		return x.EnergyCarriers
+	}
+	return nil
+}
+
+func (x *PowerSourceClassification) GetElectrified() bool {
+	if x != nil {
+		return x.Electrified
+	}
+	return false
+}
+
+func (x *PowerSourceClassification) GetHybridType() string {
+	if x != nil {
+		return x.HybridType
+	}
+	return ""
+}
+
+func (x *PowerSourceClassification) GetFuelCell() bool {
+	if x != nil {
+		return x.FuelCell
+	}
+	return false
+}
+
+func (x *PowerSourceClassification) GetBivalent() bool {
+	if x != nil {
+		return x.Bivalent
+	}
+	return false
+}
+
+// WMI is a world manufacturer identifier.
+type WMI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Wmi     string `protobuf:"bytes,1,opt,name=wmi,proto3" json:"wmi,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Country string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+}
+
+func (x *WMI) Reset() {
+	*x = WMI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WMI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WMI) ProtoMessage() {}
+
+func (x *WMI) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WMI.ProtoReflect.Descriptor instead.
+func (*WMI) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WMI) GetWmi() string {
+	if x != nil {
+		return x.Wmi
+	}
+	return ""
+}
+
+func (x *WMI) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WMI) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+// VIN is a decoded vehicle identification number.
+type VIN struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin             string          `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	Wmi             *WMI            `protobuf:"bytes,2,opt,name=wmi,proto3" json:"wmi,omitempty"`
+	Vds             string          `protobuf:"bytes,3,opt,name=vds,proto3" json:"vds,omitempty"`
+	Vis             string          `protobuf:"bytes,4,opt,name=vis,proto3" json:"vis,omitempty"`
+	ModelYears      []int32         `protobuf:"varint,5,rep,packed,name=model_years,json=modelYears,proto3" json:"model_years,omitempty"`
+	CheckDigitValid bool            `protobuf:"varint,6,opt,name=check_digit_valid,json=checkDigitValid,proto3" json:"check_digit_valid,omitempty"`
+	Manufacturers   []*Manufacturer `protobuf:"bytes,7,rep,name=manufacturers,proto3" json:"manufacturers,omitempty"`
+}
+
+func (x *VIN) Reset() {
+	*x = VIN{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VIN) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VIN) ProtoMessage() {}
+
+func (x *VIN) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VIN.ProtoReflect.Descriptor instead.
+func (*VIN) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VIN) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+func (x *VIN) GetWmi() *WMI {
+	if x != nil {
+		return x.Wmi
+	}
+	return nil
+}
+
+func (x *VIN) GetVds() string {
+	if x != nil {
+		return x.Vds
+	}
+	return ""
+}
+
+func (x *VIN) GetVis() string {
+	if x != nil {
+		return x.Vis
+	}
+	return ""
+}
+
+func (x *VIN) GetModelYears() []int32 {
+	if x != nil {
+		return x.ModelYears
+	}
+	return nil
+}
+
+func (x *VIN) GetCheckDigitValid() bool {
+	if x != nil {
+		return x.CheckDigitValid
+	}
+	return false
+}
+
+func (x *VIN) GetManufacturers() []*Manufacturer {
+	if x != nil {
+		return x.Manufacturers
+	}
+	return nil
+}
+
+type GetManufacturersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetManufacturersRequest) Reset() {
+	*x = GetManufacturersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetManufacturersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManufacturersRequest) ProtoMessage() {}
+
+func (x *GetManufacturersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManufacturersRequest.ProtoReflect.Descriptor instead.
+func (*GetManufacturersRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{7}
+}
+
+type GetManufacturersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturers []*Manufacturer `protobuf:"bytes,1,rep,name=manufacturers,proto3" json:"manufacturers,omitempty"`
+}
+
+func (x *GetManufacturersResponse) Reset() {
+	*x = GetManufacturersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetManufacturersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManufacturersResponse) ProtoMessage() {}
+
+func (x *GetManufacturersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManufacturersResponse.ProtoReflect.Descriptor instead.
+func (*GetManufacturersResponse) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetManufacturersResponse) GetManufacturers() []*Manufacturer {
+	if x != nil {
+		return x.Manufacturers
+	}
+	return nil
+}
+
+type GetManufacturerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hsn string `protobuf:"bytes,1,opt,name=hsn,proto3" json:"hsn,omitempty"`
+}
+
+func (x *GetManufacturerRequest) Reset() {
+	*x = GetManufacturerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetManufacturerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetManufacturerRequest) ProtoMessage() {}
+
+func (x *GetManufacturerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetManufacturerRequest.ProtoReflect.Descriptor instead.
+func (*GetManufacturerRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetManufacturerRequest) GetHsn() string {
+	if x != nil {
+		return x.Hsn
+	}
+	return ""
+}
+
+type GetVehiclesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hsn string `protobuf:"bytes,1,opt,name=hsn,proto3" json:"hsn,omitempty"`
+}
+
+func (x *GetVehiclesRequest) Reset() {
+	*x = GetVehiclesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesRequest) ProtoMessage() {}
+
+func (x *GetVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*GetVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetVehiclesRequest) GetHsn() string {
+	if x != nil {
+		return x.Hsn
+	}
+	return ""
+}
+
+type GetVehiclesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vehicles []*Vehicle `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+}
+
+func (x *GetVehiclesResponse) Reset() {
+	*x = GetVehiclesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVehiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesResponse) ProtoMessage() {}
+
+func (x *GetVehiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesResponse.ProtoReflect.Descriptor instead.
+func (*GetVehiclesResponse) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetVehiclesResponse) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+type GetVehicleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hsn string `protobuf:"bytes,1,opt,name=hsn,proto3" json:"hsn,omitempty"`
+	Tsn string `protobuf:"bytes,2,opt,name=tsn,proto3" json:"tsn,omitempty"`
+	// language of the power source texts, defaults to German.
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *GetVehicleRequest) Reset() {
+	*x = GetVehicleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleRequest) ProtoMessage() {}
+
+func (x *GetVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetVehicleRequest) GetHsn() string {
+	if x != nil {
+		return x.Hsn
+	}
+	return ""
+}
+
+func (x *GetVehicleRequest) GetTsn() string {
+	if x != nil {
+		return x.Tsn
+	}
+	return ""
+}
+
+func (x *GetVehicleRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type GetPowerSourcesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// language of the power source texts, defaults to German.
+	Language string `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *GetPowerSourcesRequest) Reset() {
+	*x = GetPowerSourcesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPowerSourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerSourcesRequest) ProtoMessage() {}
+
+func (x *GetPowerSourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerSourcesRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerSourcesRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPowerSourcesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type GetPowerSourcesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PowerSources []*PowerSource `protobuf:"bytes,1,rep,name=power_sources,json=powerSources,proto3" json:"power_sources,omitempty"`
+}
+
+func (x *GetPowerSourcesResponse) Reset() {
+	*x = GetPowerSourcesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPowerSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerSourcesResponse) ProtoMessage() {}
+
+func (x *GetPowerSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerSourcesResponse.ProtoReflect.Descriptor instead.
+func (*GetPowerSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetPowerSourcesResponse) GetPowerSources() []*PowerSource {
+	if x != nil {
+		return x.PowerSources
+	}
+	return nil
+}
+
+type GetPowerSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// language of the power source texts, defaults to German.
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *GetPowerSourceRequest) Reset() {
+	*x = GetPowerSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPowerSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPowerSourceRequest) ProtoMessage() {}
+
+func (x *GetPowerSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPowerSourceRequest.ProtoReflect.Descriptor instead.
+func (*GetPowerSourceRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetPowerSourceRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetPowerSourceRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type GetVINRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vin string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+}
+
+func (x *GetVINRequest) Reset() {
+	*x = GetVINRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehicles_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVINRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVINRequest) ProtoMessage() {}
+
+func (x *GetVINRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehicles_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVINRequest.ProtoReflect.Descriptor instead.
+func (*GetVINRequest) Descriptor() ([]byte, []int) {
+	return file_vehicles_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetVINRequest) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+var File_vehicles_proto protoreflect.FileDescriptor
+
+var file_vehicles_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x15, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x73, 0x0a, 0x0c, 0x4d, 0x61, 0x6e, 0x75, 0x66,
+	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x73, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x68, 0x73, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3d, 0x0a,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x10,
+	0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xd0, 0x05, 0x0a, 0x07, 0x56, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x73, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x68, 0x73, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x73, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x73, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x6d, 0x61, 0x6e, 0x75,
+	0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x10, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x64, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x69,
+	0x61, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x65, 0x72, 0x63, 0x69, 0x61, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x64, 0x79, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x62, 0x6f, 0x64, 0x79, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x12, 0x2c, 0x0a, 0x0f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0e, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01,
+	0x12, 0x19, 0x0a, 0x05, 0x61, 0x78, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x01, 0x52, 0x05, 0x61, 0x78, 0x6c, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x70,
+	0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x78, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x05, 0x48, 0x02, 0x52, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x41, 0x78, 0x6c,
+	0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x26, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x6d, 0x61, 0x73, 0x73,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x48, 0x04, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75,
+	0x6d, 0x4d, 0x61, 0x73, 0x73, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x45, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63,
+	0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0b, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66,
+	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x72, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72,
+	0x42, 0x12, 0x0a, 0x10, 0x5f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x61, 0x78, 0x6c, 0x65, 0x73, 0x42, 0x10,
+	0x0a, 0x0e, 0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x78, 0x6c, 0x65, 0x73,
+	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d,
+	0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x5f, 0x6d, 0x61, 0x73, 0x73, 0x22, 0xad, 0x01, 0x0a, 0x0b,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x58, 0x0a, 0x0e, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x65, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe1, 0x01, 0x0a, 0x19,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x75, 0x65,
+	0x6c, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x66, 0x75, 0x65, 0x6c, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x6e,
+	0x65, 0x72, 0x67, 0x79, 0x5f, 0x63, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x6e, 0x65, 0x72, 0x67, 0x79, 0x43, 0x61, 0x72, 0x72, 0x69,
+	0x65, 0x72, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x72, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x72,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x79, 0x62, 0x72, 0x69, 0x64, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x79, 0x62, 0x72,
+	0x69, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63,
+	0x65, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x75, 0x65, 0x6c, 0x43,
+	0x65, 0x6c, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x69, 0x76, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x69, 0x76, 0x61, 0x6c, 0x65, 0x6e, 0x74, 0x22,
+	0x45, 0x0a, 0x03, 0x57, 0x4d, 0x49, 0x12, 0x10, 0x0a, 0x03, 0x77, 0x6d, 0x69, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x77, 0x6d, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x81, 0x02, 0x0a, 0x03, 0x56, 0x49, 0x4e, 0x12, 0x10,
+	0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e,
+	0x12, 0x2c, 0x0a, 0x03, 0x77, 0x6d, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x4d, 0x49, 0x52, 0x03, 0x77, 0x6d, 0x69, 0x12, 0x10,
+	0x0a, 0x03, 0x76, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x64, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76,
+	0x69, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x79, 0x65, 0x61, 0x72,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x59, 0x65,
+	0x61, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x64, 0x69, 0x67,
+	0x69, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x44, 0x69, 0x67, 0x69, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12,
+	0x49, 0x0a, 0x0d, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63,
+	0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x52, 0x0d, 0x6d, 0x61, 0x6e,
+	0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x65, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x75,
+	0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x0d, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x52, 0x0d, 0x6d,
+	0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x22, 0x2a, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x73, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x68, 0x73, 0x6e, 0x22, 0x26, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x68, 0x73, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x68, 0x73, 0x6e,
+	0x22, 0x51, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x73, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x68, 0x73, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x73,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x73, 0x6e, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x34, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x62,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0d, 0x70, 0x6f, 0x77,
+	0x65, 0x72, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x22, 0x43, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x21, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x56, 0x49,
+	0x4e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x32, 0xc6, 0x05, 0x0a, 0x08, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x73, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4d, 0x61,
+	0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x2e, 0x65, 0x6e,
+	0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x65, 0x6e,
+	0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12,
+	0x2d, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x75, 0x66,
+	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x12, 0x29, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x28, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f,
+	0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x12, 0x70, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x12, 0x2d, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72,
+	0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e,
+	0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2c, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61,
+	0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e,
+	0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65,
+	0x72, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x56, 0x49,
+	0x4e, 0x12, 0x24, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x63, 0x61, 0x72, 0x2e, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x49, 0x4e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f,
+	0x63, 0x61, 0x72, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x49, 0x4e, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x43, 0x61, 0x72, 0x2f, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x2f, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_vehicles_proto_rawDescOnce sync.Once
+	file_vehicles_proto_rawDescData = file_vehicles_proto_rawDesc
+)
+
+func file_vehicles_proto_rawDescGZIP() []byte {
+	file_vehicles_proto_rawDescOnce.Do(func() {
+		file_vehicles_proto_rawDescData = protoimpl.X.CompressGZIP(file_vehicles_proto_rawDescData)
+	})
+	return file_vehicles_proto_rawDescData
+}
+
+var file_vehicles_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_vehicles_proto_goTypes = []any{
+	(*Manufacturer)(nil),              // 0: envirocar.vehicles.v1.Manufacturer
+	(*ManufacturerName)(nil),          // 1: envirocar.vehicles.v1.ManufacturerName
+	(*Vehicle)(nil),                   // 2: envirocar.vehicles.v1.Vehicle
+	(*PowerSource)(nil),               // 3: envirocar.vehicles.v1.PowerSource
+	(*PowerSourceClassification)(nil), // 4: envirocar.vehicles.v1.PowerSourceClassification
+	(*WMI)(nil),                       // 5: envirocar.vehicles.v1.WMI
+	(*VIN)(nil),                       // 6: envirocar.vehicles.v1.VIN
+	(*GetManufacturersRequest)(nil),   // 7: envirocar.vehicles.v1.GetManufacturersRequest
+	(*GetManufacturersResponse)(nil),  // 8: envirocar.vehicles.v1.GetManufacturersResponse
+	(*GetManufacturerRequest)(nil),    // 9: envirocar.vehicles.v1.GetManufacturerRequest
+	(*GetVehiclesRequest)(nil),        // 10: envirocar.vehicles.v1.GetVehiclesRequest
+	(*GetVehiclesResponse)(nil),       // 11: envirocar.vehicles.v1.GetVehiclesResponse
+	(*GetVehicleRequest)(nil),         // 12: envirocar.vehicles.v1.GetVehicleRequest
+	(*GetPowerSourcesRequest)(nil),    // 13: envirocar.vehicles.v1.GetPowerSourcesRequest
+	(*GetPowerSourcesResponse)(nil),   // 14: envirocar.vehicles.v1.GetPowerSourcesResponse
+	(*GetPowerSourceRequest)(nil),     // 15: envirocar.vehicles.v1.GetPowerSourceRequest
+	(*GetVINRequest)(nil),             // 16: envirocar.vehicles.v1.GetVINRequest
+}
+var file_vehicles_proto_depIdxs = []int32{
+	1,  // 0: envirocar.vehicles.v1.Manufacturer.names:type_name -> envirocar.vehicles.v1.ManufacturerName
+	3,  // 1: envirocar.vehicles.v1.Vehicle.power_source:type_name -> envirocar.vehicles.v1.PowerSource
+	0,  // 2: envirocar.vehicles.v1.Vehicle.manufacturer:type_name -> envirocar.vehicles.v1.Manufacturer
+	4,  // 3: envirocar.vehicles.v1.PowerSource.classification:type_name -> envirocar.vehicles.v1.PowerSourceClassification
+	5,  // 4: envirocar.vehicles.v1.VIN.wmi:type_name -> envirocar.vehicles.v1.WMI
+	0,  // 5: envirocar.vehicles.v1.VIN.manufacturers:type_name -> envirocar.vehicles.v1.Manufacturer
+	0,  // 6: envirocar.vehicles.v1.GetManufacturersResponse.manufacturers:type_name -> envirocar.vehicles.v1.Manufacturer
+	2,  // 7: envirocar.vehicles.v1.GetVehiclesResponse.vehicles:type_name -> envirocar.vehicles.v1.Vehicle
+	3,  // 8: envirocar.vehicles.v1.GetPowerSourcesResponse.power_sources:type_name -> envirocar.vehicles.v1.PowerSource
+	7,  // 9: envirocar.vehicles.v1.Vehicles.GetManufacturers:input_type -> envirocar.vehicles.v1.GetManufacturersRequest
+	9,  // 10: envirocar.vehicles.v1.Vehicles.GetManufacturer:input_type -> envirocar.vehicles.v1.GetManufacturerRequest
+	10, // 11: envirocar.vehicles.v1.Vehicles.GetVehicles:input_type -> envirocar.vehicles.v1.GetVehiclesRequest
+	12, // 12: envirocar.vehicles.v1.Vehicles.GetVehicle:input_type -> envirocar.vehicles.v1.GetVehicleRequest
+	13, // 13: envirocar.vehicles.v1.Vehicles.GetPowerSources:input_type -> envirocar.vehicles.v1.GetPowerSourcesRequest
+	15, // 14: envirocar.vehicles.v1.Vehicles.GetPowerSource:input_type -> envirocar.vehicles.v1.GetPowerSourceRequest
+	16, // 15: envirocar.vehicles.v1.Vehicles.GetVIN:input_type -> envirocar.vehicles.v1.GetVINRequest
+	8,  // 16: envirocar.vehicles.v1.Vehicles.GetManufacturers:output_type -> envirocar.vehicles.v1.GetManufacturersResponse
+	0,  // 17: envirocar.vehicles.v1.Vehicles.GetManufacturer:output_type -> envirocar.vehicles.v1.Manufacturer
+	11, // 18: envirocar.vehicles.v1.Vehicles.GetVehicles:output_type -> envirocar.vehicles.v1.GetVehiclesResponse
+	2,  // 19: envirocar.vehicles.v1.Vehicles.GetVehicle:output_type -> envirocar.vehicles.v1.Vehicle
+	14, // 20: envirocar.vehicles.v1.Vehicles.GetPowerSources:output_type -> envirocar.vehicles.v1.GetPowerSourcesResponse
+	3,  // 21: envirocar.vehicles.v1.Vehicles.GetPowerSource:output_type -> envirocar.vehicles.v1.PowerSource
+	6,  // 22: envirocar.vehicles.v1.Vehicles.GetVIN:output_type -> envirocar.vehicles.v1.VIN
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_vehicles_proto_init() }
+func file_vehicles_proto_init() {
+	if File_vehicles_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_vehicles_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Manufacturer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ManufacturerName); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Vehicle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*PowerSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*PowerSourceClassification); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*WMI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*VIN); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*GetManufacturersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*GetManufacturersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetManufacturerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GetVehiclesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GetVehiclesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetVehicleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPowerSourcesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPowerSourcesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPowerSourceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehicles_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetVINRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_vehicles_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_vehicles_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vehicles_proto_goTypes,
+		DependencyIndexes: file_vehicles_proto_depIdxs,
+		MessageInfos:      file_vehicles_proto_msgTypes,
+	}.Build()
+	File_vehicles_proto = out.File
+	file_vehicles_proto_rawDesc = nil
+	file_vehicles_proto_goTypes = nil
+	file_vehicles_proto_depIdxs = nil
+}
@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: vehicles.proto
+
+package vehiclespb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Vehicles_GetManufacturers_FullMethodName = "/envirocar.vehicles.v1.Vehicles/GetManufacturers"
+	Vehicles_GetManufacturer_FullMethodName  = "/envirocar.vehicles.v1.Vehicles/GetManufacturer"
+	Vehicles_GetVehicles_FullMethodName      = "/envirocar.vehicles.v1.Vehicles/GetVehicles"
+	Vehicles_GetVehicle_FullMethodName       = "/envirocar.vehicles.v1.Vehicles/GetVehicle"
+	Vehicles_GetPowerSources_FullMethodName  = "/envirocar.vehicles.v1.Vehicles/GetPowerSources"
+	Vehicles_GetPowerSource_FullMethodName   = "/envirocar.vehicles.v1.Vehicles/GetPowerSource"
+	Vehicles_GetVIN_FullMethodName           = "/envirocar.vehicles.v1.Vehicles/GetVIN"
+)
+
+// VehiclesClient is the client API for Vehicles service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Vehicles looks up vehicle types of the German KBA register.
+type VehiclesClient interface {
+	// GetManufacturers returns all manufacturers.
+	GetManufacturers(ctx context.Context, in *GetManufacturersRequest, opts ...grpc.CallOption) (*GetManufacturersResponse, error)
+	// GetManufacturer returns the specified manufacturer.
+	GetManufacturer(ctx context.Context, in *GetManufacturerRequest, opts ...grpc.CallOption) (*Manufacturer, error)
+	// GetVehicles returns all vehicles of the manufacturer.
+	GetVehicles(ctx context.Context, in *GetVehiclesRequest, opts ...grpc.CallOption) (*GetVehiclesResponse, error)
+	// GetVehicle returns the specified vehicle.
+	GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// GetPowerSources returns all power sources.
+	GetPowerSources(ctx context.Context, in *GetPowerSourcesRequest, opts ...grpc.CallOption) (*GetPowerSourcesResponse, error)
+	// GetPowerSource returns the specified power source.
+	GetPowerSource(ctx context.Context, in *GetPowerSourceRequest, opts ...grpc.CallOption) (*PowerSource, error)
+	// GetVIN decodes the VIN and returns the candidate manufacturers.
+	GetVIN(ctx context.Context, in *GetVINRequest, opts ...grpc.CallOption) (*VIN, error)
+}
+
+type vehiclesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVehiclesClient(cc grpc.ClientConnInterface) VehiclesClient {
+	return &vehiclesClient{cc}
+}
+
+func (c *vehiclesClient) GetManufacturers(ctx context.Context, in *GetManufacturersRequest, opts ...grpc.CallOption) (*GetManufacturersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetManufacturersResponse)
+	err := c.cc.Invoke(ctx, Vehicles_GetManufacturers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehiclesClient) GetManufacturer(ctx context.Context, in *GetManufacturerRequest, opts ...grpc.CallOption) (*Manufacturer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Manufacturer)
+	err := c.cc.Invoke(ctx, Vehicles_GetManufacturer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehiclesClient) GetVehicles(ctx context.Context, in *GetVehiclesRequest, opts ...grpc.CallOption) (*GetVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehiclesResponse)
+	err := c.cc.Invoke(ctx, Vehicles_GetVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehiclesClient) GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, Vehicles_GetVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehiclesClient) GetPowerSources(ctx context.Context, in *GetPowerSourcesRequest, opts ...grpc.CallOption) (*GetPowerSourcesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPowerSourcesResponse)
+	err := c.cc.Invoke(ctx, Vehicles_GetPowerSources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehiclesClient) GetPowerSource(ctx context.Context, in *GetPowerSourceRequest, opts ...grpc.CallOption) (*PowerSource, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PowerSource)
+	err := c.cc.Invoke(ctx, Vehicles_GetPowerSource_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehiclesClient) GetVIN(ctx context.Context, in *GetVINRequest, opts ...grpc.CallOption) (*VIN, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VIN)
+	err := c.cc.Invoke(ctx, Vehicles_GetVIN_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VehiclesServer is the server API for Vehicles service.
+// All implementations must embed UnimplementedVehiclesServer
+// for forward compatibility.
+//
+// Vehicles looks up vehicle types of the German KBA register.
+type VehiclesServer interface {
+	// GetManufacturers returns all manufacturers.
+	GetManufacturers(context.Context, *GetManufacturersRequest) (*GetManufacturersResponse, error)
+	// GetManufacturer returns the specified manufacturer.
+	GetManufacturer(context.Context, *GetManufacturerRequest) (*Manufacturer, error)
+	// GetVehicles returns all vehicles of the manufacturer.
+	GetVehicles(context.Context, *GetVehiclesRequest) (*GetVehiclesResponse, error)
+	// GetVehicle returns the specified vehicle.
+	GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error)
+	// GetPowerSources returns all power sources.
+	GetPowerSources(context.Context, *GetPowerSourcesRequest) (*GetPowerSourcesResponse, error)
+	// GetPowerSource returns the specified power source.
+	GetPowerSource(context.Context, *GetPowerSourceRequest) (*PowerSource, error)
+	// GetVIN decodes the VIN and returns the candidate manufacturers.
+	GetVIN(context.Context, *GetVINRequest) (*VIN, error)
+	mustEmbedUnimplementedVehiclesServer()
+}
+
+// UnimplementedVehiclesServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVehiclesServer struct{}
+
+func (UnimplementedVehiclesServer) GetManufacturers(context.Context, *GetManufacturersRequest) (*GetManufacturersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManufacturers not implemented")
+}
+func (UnimplementedVehiclesServer) GetManufacturer(context.Context, *GetManufacturerRequest) (*Manufacturer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManufacturer not implemented")
+}
+func (UnimplementedVehiclesServer) GetVehicles(context.Context, *GetVehiclesRequest) (*GetVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicles not implemented")
+}
+func (UnimplementedVehiclesServer) GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicle not implemented")
+}
+func (UnimplementedVehiclesServer) GetPowerSources(context.Context, *GetPowerSourcesRequest) (*GetPowerSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPowerSources not implemented")
+}
+func (UnimplementedVehiclesServer) GetPowerSource(context.Context, *GetPowerSourceRequest) (*PowerSource, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPowerSource not implemented")
+}
+func (UnimplementedVehiclesServer) GetVIN(context.Context, *GetVINRequest) (*VIN, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVIN not implemented")
+}
+func (UnimplementedVehiclesServer) mustEmbedUnimplementedVehiclesServer() {}
+func (UnimplementedVehiclesServer) testEmbeddedByValue()                  {}
+
+// UnsafeVehiclesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VehiclesServer will
+// result in compilation errors.
+type UnsafeVehiclesServer interface {
+	mustEmbedUnimplementedVehiclesServer()
+}
+
+func RegisterVehiclesServer(s grpc.ServiceRegistrar, srv VehiclesServer) {
+	// If the following call pancis, it indicates UnimplementedVehiclesServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Vehicles_ServiceDesc, srv)
+}
+
+func _Vehicles_GetManufacturers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManufacturersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetManufacturers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetManufacturers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetManufacturers(ctx, req.(*GetManufacturersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vehicles_GetManufacturer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetManufacturerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetManufacturer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetManufacturer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetManufacturer(ctx, req.(*GetManufacturerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vehicles_GetVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetVehicles(ctx, req.(*GetVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vehicles_GetVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetVehicle(ctx, req.(*GetVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vehicles_GetPowerSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPowerSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetPowerSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetPowerSources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetPowerSources(ctx, req.(*GetPowerSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vehicles_GetPowerSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPowerSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetPowerSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetPowerSource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetPowerSource(ctx, req.(*GetPowerSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vehicles_GetVIN_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVINRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehiclesServer).GetVIN(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Vehicles_GetVIN_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehiclesServer).GetVIN(ctx, req.(*GetVINRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Vehicles_ServiceDesc is the grpc.ServiceDesc for Vehicles service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Vehicles_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "envirocar.vehicles.v1.Vehicles",
+	HandlerType: (*VehiclesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetManufacturers",
+			Handler:    _Vehicles_GetManufacturers_Handler,
+		},
+		{
+			MethodName: "GetManufacturer",
+			Handler:    _Vehicles_GetManufacturer_Handler,
+		},
+		{
+			MethodName: "GetVehicles",
+			Handler:    _Vehicles_GetVehicles_Handler,
+		},
+		{
+			MethodName: "GetVehicle",
+			Handler:    _Vehicles_GetVehicle_Handler,
+		},
+		{
+			MethodName: "GetPowerSources",
+			Handler:    _Vehicles_GetPowerSources_Handler,
+		},
+		{
+			MethodName: "GetPowerSource",
+			Handler:    _Vehicles_GetPowerSource_Handler,
+		},
+		{
+			MethodName: "GetVIN",
+			Handler:    _Vehicles_GetVIN_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vehicles.proto",
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// WMI is a world manufacturer identifier.
+type WMI struct {
+	ID      string `pg:",pk" json:"wmi,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// VIN is a decoded vehicle identification number.
+type VIN struct {
+	Linked
+	VIN             string          `json:"vin"`
+	WMI             *WMI            `json:"wmi"`
+	VDS             string          `json:"vds"`
+	VIS             string          `json:"vis"`
+	ModelYears      []int           `json:"modelYears,omitempty"`
+	CheckDigitValid bool            `json:"checkDigitValid"`
+	Manufacturers   []*Manufacturer `json:"manufacturers,omitempty"`
+}
+
+func (v *VIN) String() string {
+	bytes, _ := json.Marshal(v)
+	return string(bytes)
+}
+
+func (v *VIN) identity() (string, string) {
+	return "vins", v.VIN
+}
+
+const (
+	vinLength = 17
+	// vinCheckDigitIndex is the position of the check digit.
+	vinCheckDigitIndex = 8
+	// vinModelYearIndex is the position of the model year code.
+	vinModelYearIndex = 9
+	// vinModelYearCodes are the model year codes, repeating every 30 years
+	// starting with 1980.
+	vinModelYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+)
+
+var vinWeights = [vinLength]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// vinValue transliterates a VIN character to its numeric value. It returns
+// -1 for characters that are not allowed in a VIN.
+func vinValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1
+	case c == 'P':
+		return 7
+	case c == 'R':
+		return 9
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2
+	}
+	return -1
+}
+
+// vinCheckDigit computes the check digit of the VIN.
+func vinCheckDigit(vin string) byte {
+	sum := 0
+	for i := 0; i < vinLength; i++ {
+		sum += vinValue(vin[i]) * vinWeights[i]
+	}
+	if rem := sum % 11; rem != 10 {
+		return byte('0' + rem)
+	}
+	return 'X'
+}
+
+// vinRequiresCheckDigit returns whether the check digit is mandatory for the
+// region of the VIN (North America and China).
+func vinRequiresCheckDigit(vin string) bool {
+	return (vin[0] >= '1' && vin[0] <= '5') || vin[0] == 'L'
+}
+
+// vinModelYears decodes the possible model years of the VIN. North American
+// VINs disambiguate the 30 year cycle by the seventh character, for all other
+// VINs every year up to next year is a candidate.
+func vinModelYears(vin string, maxYear int) []int {
+	idx := strings.IndexByte(vinModelYearCodes, vin[vinModelYearIndex])
+	if idx < 0 {
+		return nil
+	}
+	var years []int
+	for year := 1980 + idx; year <= maxYear; year += len(vinModelYearCodes) {
+		years = append(years, year)
+	}
+	if vin[0] >= '1' && vin[0] <= '5' && len(years) > 1 {
+		if vin[6] >= '0' && vin[6] <= '9' {
+			return years[:1]
+		}
+		return years[1:2]
+	}
+	return years
+}
+
+// ParseVIN validates and decodes the vehicle identification number.
+func ParseVIN(s string) (*VIN, error) {
+	vin := strings.ToUpper(strings.TrimSpace(s))
+	if len(vin) != vinLength {
+		return nil, NewErrBadRequestF("VIN must have %d characters: '%s'", vinLength, s)
+	}
+	for i := 0; i < vinLength; i++ {
+		if vinValue(vin[i]) < 0 {
+			return nil, NewErrBadRequestF("VIN contains invalid character '%c': '%s'", vin[i], s)
+		}
+	}
+
+	checkDigitValid := vinCheckDigit(vin) == vin[vinCheckDigitIndex]
+	if !checkDigitValid && vinRequiresCheckDigit(vin) {
+		return nil, NewErrBadRequestF("VIN check digit is invalid: '%s'", s)
+	}
+
+	return &VIN{
+		VIN:             vin,
+		WMI:             &WMI{ID: vin[0:3]},
+		VDS:             vin[3:9],
+		VIS:             vin[9:],
+		ModelYears:      vinModelYears(vin, time.Now().Year()+1),
+		CheckDigitValid: checkDigitValid,
+	}, nil
+}
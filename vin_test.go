@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestParseVIN(t *testing.T) {
+
+	t.Log("parse north american vin")
+	vin, err := ParseVIN(" 1m8gdm9axkp042788 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vin.VIN != "1M8GDM9AXKP042788" {
+		t.Fatalf("vin is bad, got:'%v', want:'%v'", vin.VIN, "1M8GDM9AXKP042788")
+	}
+	if vin.WMI.ID != "1M8" {
+		t.Fatalf("wmi is bad, got:'%v', want:'%v'", vin.WMI.ID, "1M8")
+	}
+	if !vin.CheckDigitValid {
+		t.Fatal("check digit is not valid")
+	}
+	if want := []int{1989}; !reflect.DeepEqual(vin.ModelYears, want) {
+		t.Fatalf("model years are bad, got:'%v', want:'%v'", vin.ModelYears, want)
+	}
+	t.Log(vin)
+}
+
+func TestParseVINWithoutCheckDigit(t *testing.T) {
+
+	t.Log("parse european vin without check digit")
+	vin, err := ParseVIN("WBAEH71010B123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vin.CheckDigitValid {
+		t.Fatal("check digit is valid")
+	}
+	if vin.WMI.ID != "WBA" {
+		t.Fatalf("wmi is bad, got:'%v', want:'%v'", vin.WMI.ID, "WBA")
+	}
+	if len(vin.ModelYears) != 0 {
+		t.Fatalf("model years are bad, got:'%v', want none", vin.ModelYears)
+	}
+	t.Log(vin)
+}
+
+func TestParseVINModelYears(t *testing.T) {
+
+	t.Log("parse european vin with model year")
+	vin, err := ParseVIN("WVWZZZ1KZAW000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{1980, 2010}; !reflect.DeepEqual(vin.ModelYears, want) {
+		t.Fatalf("model years are bad, got:'%v', want:'%v'", vin.ModelYears, want)
+	}
+}
+
+func TestParseVINInvalid(t *testing.T) {
+
+	for _, s := range []string{
+		"",
+		"1M8GDM9AXKP04278",
+		"1M8GDM9AXKP0427888",
+		"1M8GDM9AXKP04278I",
+		"1M8GDM9A1KP042788",
+	} {
+		t.Logf("parse invalid vin '%s'", s)
+		_, err := ParseVIN(s)
+		if err == nil {
+			t.Fatal("error is nil")
+		}
+
+		httpError, ok := err.(Error)
+		if !ok {
+			t.Fatalf("%v, %T", err, err)
+		}
+
+		if httpError.Status() != http.StatusBadRequest {
+			t.Fatalf("status code is bad, got:'%v', want:'%v'", httpError.Status(), http.StatusBadRequest)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/url"
+	"strings"
 )
 
 // Link is a link.
@@ -32,12 +34,12 @@ type jsonLink struct {
 
 // UnmarshalJSON is required by json.Unmarshaler
 func (l *Link) UnmarshalJSON(bytes []byte) error {
-	var s *jsonLink
-	err := json.Unmarshal(bytes, s)
+	var s jsonLink
+	err := json.Unmarshal(bytes, &s)
 	if err != nil {
 		return err
 	}
-	return l.fromJSON(s)
+	return l.fromJSON(&s)
 }
 
 func (l *Link) fromJSON(s *jsonLink) (err error) {
@@ -62,6 +64,56 @@ func (l *Link) toJSON() *jsonLink {
 	}
 }
 
+// Header returns the link as a link-value of a HTTP Link header as of RFC
+// 8288. Titles that are not printable ASCII are encoded as of RFC 8187.
+func (l *Link) Header() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%s>", l.URL)
+	if l.Relation != "" {
+		fmt.Fprintf(&b, "; rel=%s", quoteHeaderValue(l.Relation))
+	}
+	if l.MediaType != "" {
+		fmt.Fprintf(&b, "; type=%s", quoteHeaderValue(l.MediaType))
+	}
+	if l.Title != "" {
+		if isPrintableASCII(l.Title) {
+			fmt.Fprintf(&b, "; title=%s", quoteHeaderValue(l.Title))
+		} else {
+			fmt.Fprintf(&b, "; title*=UTF-8''%s", encodeExtValue(l.Title))
+		}
+	}
+	return b.String()
+}
+
+func quoteHeaderValue(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func isPrintableASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < ' ' || value[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeExtValue percent-encodes the value except for the attr-chars of RFC
+// 8187.
+func encodeExtValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			strings.IndexByte("!#$&+-.^_`|~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
 // Linked is a linked object.
 type Linked struct {
 	Links []*Link `json:"links,omitempty"`
@@ -74,6 +126,15 @@ func (l *Linked) AddLink(link *Link) {
 	}
 }
 
+// hyperlinked is content with links, that are mirrored in Link headers.
+type hyperlinked interface {
+	links() []*Link
+}
+
+func (l *Linked) links() []*Link {
+	return l.Links
+}
+
 var (
 	_ json.Marshaler   = (*Link)(nil)
 	_ json.Unmarshaler = (*Link)(nil)
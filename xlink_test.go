@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+func TestLinkRoundTrip(t *testing.T) {
+
+	href, err := url.Parse("https://processing.envirocar.org/vehicles/manufacturers/0005")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Manufacturer{ID: "0005", Name: "BMW"}
+	m.AddLink(NewLink(href, "self", "application/json", "BMW"))
+
+	t.Log("encode manufacturer")
+	bytes, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log("decode manufacturer")
+	decoded := &Manufacturer{}
+	if err := json.Unmarshal(bytes, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded.Links) != 1 {
+		t.Fatalf("links are bad, got:'%v', want one link", decoded.Links)
+	}
+	link := decoded.Links[0]
+	if link.URL.String() != href.String() || link.Relation != "self" ||
+		link.MediaType != "application/json" || link.Title != "BMW" {
+		t.Fatalf("link is bad, got:'%v', want:'%v'", link.toJSON(), m.Links[0].toJSON())
+	}
+}
+
+func TestLinkHeader(t *testing.T) {
+
+	href, err := url.Parse("https://processing.envirocar.org/vehicles/manufacturers/0005")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		link *Link
+		want string
+	}{
+		{NewLink(href, "self", "application/json", "BMW"),
+			`<https://processing.envirocar.org/vehicles/manufacturers/0005>; rel="self"; type="application/json"; title="BMW"`},
+		{NewLink(href, "vehicles", "", `"Quoted" \ title`),
+			`<https://processing.envirocar.org/vehicles/manufacturers/0005>; rel="vehicles"; title="\"Quoted\" \\ title"`},
+		{NewLink(href, "canonical", "", "Kässbohrer"),
+			`<https://processing.envirocar.org/vehicles/manufacturers/0005>; rel="canonical"; title*=UTF-8''K%C3%A4ssbohrer`},
+	}
+	for _, test := range tests {
+		t.Logf("format link header: '%s'", test.link.Title)
+		if got := test.link.Header(); got != test.want {
+			t.Fatalf("link header is bad, got:'%v', want:'%v'", got, test.want)
+		}
+	}
+}